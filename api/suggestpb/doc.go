@@ -0,0 +1,4 @@
+// Package suggestpb contains the protobuf messages and the gRPC services of the suggest and spellchecker APIs
+package suggestpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative suggest.proto
@@ -0,0 +1,431 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: suggest.proto
+
+package suggestpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Suggest_Suggest_FullMethodName            = "/suggest.v1.Suggest/Suggest"
+	Suggest_Autocomplete_FullMethodName       = "/suggest.v1.Suggest/Autocomplete"
+	Suggest_StreamAutocomplete_FullMethodName = "/suggest.v1.Suggest/StreamAutocomplete"
+	Suggest_ListDictionaries_FullMethodName   = "/suggest.v1.Suggest/ListDictionaries"
+	Suggest_Reindex_FullMethodName            = "/suggest.v1.Suggest/Reindex"
+	Suggest_GetReindexJob_FullMethodName      = "/suggest.v1.Suggest/GetReindexJob"
+)
+
+// SuggestClient is the client API for Suggest service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SuggestClient interface {
+	// Suggest returns topK approximate strings for the given query in the dictionary
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
+	// Autocomplete returns candidates where the query string is a prefix of each candidate
+	Autocomplete(ctx context.Context, in *AutocompleteRequest, opts ...grpc.CallOption) (*AutocompleteResponse, error)
+	// StreamAutocomplete answers each query of the stream, it is intended for type-ahead
+	StreamAutocomplete(ctx context.Context, opts ...grpc.CallOption) (Suggest_StreamAutocompleteClient, error)
+	// ListDictionaries returns the list of the hosted dictionaries
+	ListDictionaries(ctx context.Context, in *ListDictionariesRequest, opts ...grpc.CallOption) (*ListDictionariesResponse, error)
+	// Reindex schedules the reload of the dictionaries from the configuration and returns the job
+	Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*ReindexResponse, error)
+	// GetReindexJob returns the state of the reindex job
+	GetReindexJob(ctx context.Context, in *GetReindexJobRequest, opts ...grpc.CallOption) (*ReindexResponse, error)
+}
+
+type suggestClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSuggestClient(cc grpc.ClientConnInterface) SuggestClient {
+	return &suggestClient{cc}
+}
+
+func (c *suggestClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, Suggest_Suggest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suggestClient) Autocomplete(ctx context.Context, in *AutocompleteRequest, opts ...grpc.CallOption) (*AutocompleteResponse, error) {
+	out := new(AutocompleteResponse)
+	err := c.cc.Invoke(ctx, Suggest_Autocomplete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suggestClient) StreamAutocomplete(ctx context.Context, opts ...grpc.CallOption) (Suggest_StreamAutocompleteClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Suggest_ServiceDesc.Streams[0], Suggest_StreamAutocomplete_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &suggestStreamAutocompleteClient{stream}
+	return x, nil
+}
+
+type Suggest_StreamAutocompleteClient interface {
+	Send(*AutocompleteRequest) error
+	Recv() (*AutocompleteResponse, error)
+	grpc.ClientStream
+}
+
+type suggestStreamAutocompleteClient struct {
+	grpc.ClientStream
+}
+
+func (x *suggestStreamAutocompleteClient) Send(m *AutocompleteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *suggestStreamAutocompleteClient) Recv() (*AutocompleteResponse, error) {
+	m := new(AutocompleteResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *suggestClient) ListDictionaries(ctx context.Context, in *ListDictionariesRequest, opts ...grpc.CallOption) (*ListDictionariesResponse, error) {
+	out := new(ListDictionariesResponse)
+	err := c.cc.Invoke(ctx, Suggest_ListDictionaries_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suggestClient) Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*ReindexResponse, error) {
+	out := new(ReindexResponse)
+	err := c.cc.Invoke(ctx, Suggest_Reindex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suggestClient) GetReindexJob(ctx context.Context, in *GetReindexJobRequest, opts ...grpc.CallOption) (*ReindexResponse, error) {
+	out := new(ReindexResponse)
+	err := c.cc.Invoke(ctx, Suggest_GetReindexJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SuggestServer is the server API for Suggest service.
+// All implementations must embed UnimplementedSuggestServer
+// for forward compatibility
+type SuggestServer interface {
+	// Suggest returns topK approximate strings for the given query in the dictionary
+	Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error)
+	// Autocomplete returns candidates where the query string is a prefix of each candidate
+	Autocomplete(context.Context, *AutocompleteRequest) (*AutocompleteResponse, error)
+	// StreamAutocomplete answers each query of the stream, it is intended for type-ahead
+	StreamAutocomplete(Suggest_StreamAutocompleteServer) error
+	// ListDictionaries returns the list of the hosted dictionaries
+	ListDictionaries(context.Context, *ListDictionariesRequest) (*ListDictionariesResponse, error)
+	// Reindex schedules the reload of the dictionaries from the configuration and returns the job
+	Reindex(context.Context, *ReindexRequest) (*ReindexResponse, error)
+	// GetReindexJob returns the state of the reindex job
+	GetReindexJob(context.Context, *GetReindexJobRequest) (*ReindexResponse, error)
+	mustEmbedUnimplementedSuggestServer()
+}
+
+// UnimplementedSuggestServer must be embedded to have forward compatible implementations.
+type UnimplementedSuggestServer struct {
+}
+
+func (UnimplementedSuggestServer) Suggest(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
+func (UnimplementedSuggestServer) Autocomplete(context.Context, *AutocompleteRequest) (*AutocompleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Autocomplete not implemented")
+}
+func (UnimplementedSuggestServer) StreamAutocomplete(Suggest_StreamAutocompleteServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAutocomplete not implemented")
+}
+func (UnimplementedSuggestServer) ListDictionaries(context.Context, *ListDictionariesRequest) (*ListDictionariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDictionaries not implemented")
+}
+func (UnimplementedSuggestServer) Reindex(context.Context, *ReindexRequest) (*ReindexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reindex not implemented")
+}
+func (UnimplementedSuggestServer) GetReindexJob(context.Context, *GetReindexJobRequest) (*ReindexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReindexJob not implemented")
+}
+func (UnimplementedSuggestServer) mustEmbedUnimplementedSuggestServer() {}
+
+// UnsafeSuggestServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SuggestServer will
+// result in compilation errors.
+type UnsafeSuggestServer interface {
+	mustEmbedUnimplementedSuggestServer()
+}
+
+func RegisterSuggestServer(s grpc.ServiceRegistrar, srv SuggestServer) {
+	s.RegisterService(&Suggest_ServiceDesc, srv)
+}
+
+func _Suggest_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuggestServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Suggest_Suggest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuggestServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Suggest_Autocomplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutocompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuggestServer).Autocomplete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Suggest_Autocomplete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuggestServer).Autocomplete(ctx, req.(*AutocompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Suggest_StreamAutocomplete_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SuggestServer).StreamAutocomplete(&suggestStreamAutocompleteServer{stream})
+}
+
+type Suggest_StreamAutocompleteServer interface {
+	Send(*AutocompleteResponse) error
+	Recv() (*AutocompleteRequest, error)
+	grpc.ServerStream
+}
+
+type suggestStreamAutocompleteServer struct {
+	grpc.ServerStream
+}
+
+func (x *suggestStreamAutocompleteServer) Send(m *AutocompleteResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *suggestStreamAutocompleteServer) Recv() (*AutocompleteRequest, error) {
+	m := new(AutocompleteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Suggest_ListDictionaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDictionariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuggestServer).ListDictionaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Suggest_ListDictionaries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuggestServer).ListDictionaries(ctx, req.(*ListDictionariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Suggest_Reindex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuggestServer).Reindex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Suggest_Reindex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuggestServer).Reindex(ctx, req.(*ReindexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Suggest_GetReindexJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReindexJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuggestServer).GetReindexJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Suggest_GetReindexJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuggestServer).GetReindexJob(ctx, req.(*GetReindexJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Suggest_ServiceDesc is the grpc.ServiceDesc for Suggest service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Suggest_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "suggest.v1.Suggest",
+	HandlerType: (*SuggestServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Suggest",
+			Handler:    _Suggest_Suggest_Handler,
+		},
+		{
+			MethodName: "Autocomplete",
+			Handler:    _Suggest_Autocomplete_Handler,
+		},
+		{
+			MethodName: "ListDictionaries",
+			Handler:    _Suggest_ListDictionaries_Handler,
+		},
+		{
+			MethodName: "Reindex",
+			Handler:    _Suggest_Reindex_Handler,
+		},
+		{
+			MethodName: "GetReindexJob",
+			Handler:    _Suggest_GetReindexJob_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAutocomplete",
+			Handler:       _Suggest_StreamAutocomplete_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "suggest.proto",
+}
+
+const (
+	SpellChecker_Predict_FullMethodName = "/suggest.v1.SpellChecker/Predict"
+)
+
+// SpellCheckerClient is the client API for SpellChecker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SpellCheckerClient interface {
+	// Predict predicts the last word of the query
+	Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*PredictResponse, error)
+}
+
+type spellCheckerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpellCheckerClient(cc grpc.ClientConnInterface) SpellCheckerClient {
+	return &spellCheckerClient{cc}
+}
+
+func (c *spellCheckerClient) Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*PredictResponse, error) {
+	out := new(PredictResponse)
+	err := c.cc.Invoke(ctx, SpellChecker_Predict_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SpellCheckerServer is the server API for SpellChecker service.
+// All implementations must embed UnimplementedSpellCheckerServer
+// for forward compatibility
+type SpellCheckerServer interface {
+	// Predict predicts the last word of the query
+	Predict(context.Context, *PredictRequest) (*PredictResponse, error)
+	mustEmbedUnimplementedSpellCheckerServer()
+}
+
+// UnimplementedSpellCheckerServer must be embedded to have forward compatible implementations.
+type UnimplementedSpellCheckerServer struct {
+}
+
+func (UnimplementedSpellCheckerServer) Predict(context.Context, *PredictRequest) (*PredictResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Predict not implemented")
+}
+func (UnimplementedSpellCheckerServer) mustEmbedUnimplementedSpellCheckerServer() {}
+
+// UnsafeSpellCheckerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpellCheckerServer will
+// result in compilation errors.
+type UnsafeSpellCheckerServer interface {
+	mustEmbedUnimplementedSpellCheckerServer()
+}
+
+func RegisterSpellCheckerServer(s grpc.ServiceRegistrar, srv SpellCheckerServer) {
+	s.RegisterService(&SpellChecker_ServiceDesc, srv)
+}
+
+func _SpellChecker_Predict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpellCheckerServer).Predict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpellChecker_Predict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpellCheckerServer).Predict(ctx, req.(*PredictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SpellChecker_ServiceDesc is the grpc.ServiceDesc for SpellChecker service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SpellChecker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "suggest.v1.SpellChecker",
+	HandlerType: (*SpellCheckerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Predict",
+			Handler:    _SpellChecker_Predict_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "suggest.proto",
+}
@@ -38,7 +38,12 @@ var evalCmd = &cobra.Command{
 			return err
 		}
 
-		tokenizer := lm.NewTokenizer(config.GetWordsAlphabet())
+		tokenizer, err := config.GetTokenizer()
+
+		if err != nil {
+			return fmt.Errorf("failed to create a tokenizer: %v", err)
+		}
+
 		scanner := bufio.NewScanner(os.Stdin)
 		fmt.Print(">> ")
 
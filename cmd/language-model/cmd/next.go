@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/lm"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+var (
+	topK int
+)
+
+func init() {
+	nextCmd.Flags().IntVarP(&topK, "topK", "k", 5, "topK elements")
+
+	rootCmd.AddCommand(nextCmd)
+}
+
+var nextCmd = &cobra.Command{
+	Use:   "next -c [config path] -k [topK]",
+	Short: "cli to predict the next word of a sentence",
+	Long:  `cli to predict the most probable words that follow after the given sentence`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := lm.ReadConfig(configPath)
+
+		if err != nil {
+			return fmt.Errorf("failed to read config file: %v", err)
+		}
+
+		directory, err := store.NewFSDirectory(config.GetOutputPath())
+
+		if err != nil {
+			return fmt.Errorf("failed to create a fs directory: %v", err)
+		}
+
+		languageModel, err := lm.RetrieveLMFromBinary(directory, config)
+
+		if err != nil {
+			return err
+		}
+
+		dict, err := dictionary.OpenCDBDictionary(config.GetDictionaryPath())
+
+		if err != nil {
+			return fmt.Errorf("failed to open a cdb dictionary: %v", err)
+		}
+
+		tokenizer, err := config.GetTokenizer()
+
+		if err != nil {
+			return fmt.Errorf("failed to create a tokenizer: %v", err)
+		}
+
+		scanner := bufio.NewScanner(os.Stdin)
+		fmt.Print(">> ")
+
+		for scanner.Scan() {
+			sentence := tokenizer.Tokenize(scanner.Text())
+			ids, err := lm.MapIntoListOfWordIDs(languageModel, sentence)
+
+			if err != nil {
+				return err
+			}
+
+			start := time.Now()
+			candidates, err := languageModel.TopNext(ids, topK)
+			elapsed := time.Since(start).String()
+
+			if err != nil {
+				return err
+			}
+
+			for _, candidate := range candidates {
+				word, err := dict.Get(candidate.WordID)
+
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("%s, score: %f\n", word, candidate.Score)
+			}
+
+			fmt.Printf("\nElapsed: %s (%d candidates)\n", elapsed, len(candidates))
+			fmt.Print(">> ")
+		}
+
+		return scanner.Err()
+	},
+}
@@ -1,10 +1,8 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/suggest-go/suggest/pkg/store"
-	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/suggest-go/suggest/pkg/lm"
@@ -37,26 +35,24 @@ var countNGramsCmd = &cobra.Command{
 
 // buildNGramsCount builds a count trie
 func buildNGramsCount(config *lm.Config) (lm.CountTrie, error) {
-	sourceFile, err := os.Open(config.GetSourcePath())
+	sources, err := config.GetSourcePaths()
 
 	if err != nil {
-		return nil, fmt.Errorf("could read source file %s", err)
+		return nil, fmt.Errorf("failed to find source files: %v", err)
 	}
 
-	defer sourceFile.Close()
+	factory, err := config.GetRetrieverFactory()
 
-	retriever := lm.NewSentenceRetriever(
-		lm.NewTokenizer(config.GetWordsAlphabet()),
-		bufio.NewReader(sourceFile),
-		config.GetSeparatorsAlphabet(),
-	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a sentence retriever: %v", err)
+	}
 
 	builder := lm.NewNGramBuilder(
 		config.StartSymbol,
 		config.EndSymbol,
 	)
 
-	return builder.Build(retriever, config.NGramOrder), nil
+	return builder.BuildFromSources(sources, factory, config.NGramOrder, config.GetWorkers())
 }
 
 // storeNGramsCount flushes the constructed count trie on FS
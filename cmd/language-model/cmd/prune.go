@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/suggest-go/suggest/pkg/lm"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+}
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune -c [config path]",
+	Short: "prunes the ngram language model for the given config",
+	Long:  `removes ngrams that do not satisfy the prune section of the config and rewrites the binary language model`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := lm.ReadConfig(configPath)
+
+		if err != nil {
+			return fmt.Errorf("couldn't read a config %v", err)
+		}
+
+		directory, err := store.NewFSDirectory(config.GetOutputPath())
+
+		if err != nil {
+			return fmt.Errorf("failed to create a fs directory: %v", err)
+		}
+
+		return lm.PruneBinaryLM(directory, config, config.Prune)
+	},
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/suggest-go/suggest/internal/spellchecker/dep"
+	"github.com/suggest-go/suggest/pkg/lm"
+)
+
+func init() {
+	buildCmd.Flags().StringVarP(&lang, "lang", "l", "", "build the index of the certain language only")
+
+	rootCmd.AddCommand(buildCmd)
+}
+
+var buildCmd = &cobra.Command{
+	Use:   "build -c [config path]",
+	Short: "builds n-gram indexes of the lm vocabularies",
+	Long:  `builds n-gram indexes of the lm vocabularies and stores them in the lm output directories`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.SetPrefix("build: ")
+		log.SetFlags(0)
+
+		configs, err := dep.ReadLanguageConfigs(configPath)
+
+		if err != nil {
+			return err
+		}
+
+		built := false
+
+		for _, c := range configs {
+			if lang != "" && lang != c.Lang {
+				continue
+			}
+
+			log.Printf("Building the index of '%s'...", c.Lang)
+			start := time.Now()
+
+			config, err := lm.ReadConfig(c.GetLMConfigPath())
+
+			if err != nil {
+				return fmt.Errorf("failed to read lm config of %s: %v", c.Lang, err)
+			}
+
+			if err := dep.BuildIndex(config, c.GetIndexDescription()); err != nil {
+				return fmt.Errorf("failed to build the index of %s: %v", c.Lang, err)
+			}
+
+			log.Printf("Time spent %s", time.Since(start))
+			built = true
+		}
+
+		if !built {
+			return fmt.Errorf("language %s is not found", lang)
+		}
+
+		return nil
+	},
+}
@@ -8,26 +8,20 @@ import (
 	"os"
 	"time"
 
-	"github.com/suggest-go/suggest/pkg/lm"
-	"github.com/suggest-go/suggest/pkg/suggest"
+	"github.com/suggest-go/suggest/pkg/spellchecker"
 )
 
 var (
-	indexDescription = suggest.IndexDescription{
-		Driver:    suggest.RAMDriver,
-		Name:      "words",
-		NGramSize: 3,
-		Wrap:      [2]string{"^", "$"},
-		Pad:       "$",
-		Alphabet:  []string{"english", "russian", "numbers", "$^'"},
-	}
-
 	topK = 5
 
 	similarity = 0.5
+
+	lang string
 )
 
 func init() {
+	evalCmd.Flags().StringVarP(&lang, "lang", "l", "", "language of the spellchecker, detected by the query if empty")
+
 	rootCmd.AddCommand(evalCmd)
 }
 
@@ -36,15 +30,15 @@ var evalCmd = &cobra.Command{
 	Short: "spellchecker cli",
 	Long:  `spellchecker cli`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		config, err := lm.ReadConfig(configPath)
+		configs, err := dep.ReadLanguageConfigs(configPath)
 
 		if err != nil {
-			return fmt.Errorf("failed to read config file: %v", err)
+			return err
 		}
 
-		service, err := dep.BuildSpellChecker(config, indexDescription)
+		service := spellchecker.NewService()
 
-		if err != nil {
+		if err := dep.ConfigureService(service, configs, errorModelPath, errorWeight); err != nil {
 			return err
 		}
 
@@ -59,19 +53,29 @@ var evalCmd = &cobra.Command{
 				continue
 			}
 
+			checker, err := service.GetSpellChecker(lang, sentence)
+
+			if err != nil {
+				return err
+			}
+
 			start := time.Now()
-			result, err := service.Predict(sentence, topK, similarity)
+			result, err := checker.Predict(sentence, topK, similarity)
 			elapsed := time.Since(start).String()
 
 			if err != nil {
 				return err
 			}
 
-			for _, item := range result {
-				fmt.Printf("%s\n", item)
+			if result.KeepOriginal {
+				fmt.Printf("%s is spelled correctly (confidence: %.2f)\n\n", result.Original, result.Confidence)
+			}
+
+			for _, item := range result.Candidates {
+				fmt.Printf("%s (%s, lm: %.4f, similarity: %.2f)\n", item.Value, item.Source, item.LMScore, item.Similarity)
 			}
 
-			fmt.Printf("\nElapsed: %s (%d candidates)\n", elapsed, len(result))
+			fmt.Printf("\nElapsed: %s (%d candidates)\n", elapsed, len(result.Candidates))
 			fmt.Print(">> ")
 		}
 
@@ -8,7 +8,9 @@ import (
 )
 
 var (
-	configPath string
+	configPath     string
+	errorModelPath string
+	errorWeight    float64
 )
 
 var rootCmd = &cobra.Command{
@@ -18,9 +20,12 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "path to the config file")
+	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "path to the languages config file (or to a single lm config)")
 	rootCmd.MarkPersistentFlagFilename("config")
 	rootCmd.MarkPersistentFlagRequired("config")
+
+	rootCmd.PersistentFlags().StringVarP(&errorModelPath, "error-model", "", "", "path to the file with (misspelling, correction) pairs")
+	rootCmd.PersistentFlags().Float64VarP(&errorWeight, "error-weight", "", 1, "weight of the error model score")
 }
 
 // Execute runs commands handling
@@ -8,11 +8,15 @@ import (
 )
 
 var (
-	port string
+	port        string
+	grpcPort    string
+	disableHTTP bool
 )
 
 func init() {
 	spellcheckerCmd.Flags().StringVarP(&port, "port", "p", "8080", "listen port")
+	spellcheckerCmd.Flags().StringVarP(&grpcPort, "grpc-port", "", "", "gRPC listen port, gRPC server is disabled if empty")
+	spellcheckerCmd.Flags().BoolVarP(&disableHTTP, "disable-http", "", false, "disables http server")
 
 	rootCmd.AddCommand(spellcheckerCmd)
 }
@@ -20,15 +24,18 @@ func init() {
 var spellcheckerCmd = &cobra.Command{
 	Use:   "service-run -c [config path] -p [port]",
 	Short: "runs http server",
-	Long:  "runs http server with REST API",
+	Long:  "runs http server with REST API and (or) gRPC server",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.SetPrefix("spellchecker: ")
 		log.SetFlags(0)
 
 		config := api.AppConfig{
-			Port:       port,
-			ConfigPath: configPath,
-			IndexDescription: indexDescription,
+			Port:           port,
+			ConfigPath:     configPath,
+			ErrorModelPath: errorModelPath,
+			ErrorWeight:    errorWeight,
+			GRPCPort:       grpcPort,
+			DisableHTTP:    disableHTTP,
 		}
 
 		app := api.NewApp(config)
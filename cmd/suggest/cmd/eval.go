@@ -2,28 +2,45 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/suggest-go/suggest/pkg/metric"
 	"github.com/suggest-go/suggest/pkg/suggest"
 
 	"github.com/spf13/cobra"
 )
 
+// evalChunkSize is the number of queries of the input file processed by one batch
+const evalChunkSize = 1000
+
 var (
 	topK       int
 	similarity float64
+	metricName string
+	inputPath  string
+	outputPath string
 )
 
+// evalResult is a line of the eval output
+type evalResult struct {
+	Query string               `json:"query"`
+	Items []suggest.ResultItem `json:"items"`
+	Error string               `json:"error,omitempty"`
+}
+
 func init() {
 	evalCmd.Flags().StringVarP(&dict, "dict", "d", "", "dictionary name")
 	evalCmd.MarkPersistentFlagRequired("dict")
 
-	evalCmd.Flags().IntVarP(&topK, "topK", "k", 5, "topK elements")
-	evalCmd.Flags().Float64VarP(&similarity, "sim", "s", 0.5, "similarity of candidates")
+	evalCmd.Flags().IntVarP(&topK, "topK", "k", 0, "topK elements, the default one of the dictionary if 0")
+	evalCmd.Flags().Float64VarP(&similarity, "sim", "s", 0, "similarity of candidates, the default one of the dictionary if 0")
+	evalCmd.Flags().StringVarP(&metricName, "metric", "m", "", "metric name, the default one of the dictionary if empty")
+	evalCmd.Flags().StringVarP(&inputPath, "input", "i", "", "file of queries, one per line, evaluated in batch instead of the interactive mode")
+	evalCmd.Flags().StringVarP(&outputPath, "output", "o", "", "file of JSONL results of the input queries, stdout if empty")
 
 	rootCmd.AddCommand(evalCmd)
 }
@@ -39,6 +56,10 @@ var evalCmd = &cobra.Command{
 			return err
 		}
 
+		if inputPath != "" {
+			return evalFile(suggestService)
+		}
+
 		scanner := bufio.NewScanner(os.Stdin)
 		fmt.Print(">> ")
 
@@ -50,7 +71,12 @@ var evalCmd = &cobra.Command{
 				continue
 			}
 
-			searchConf, err := suggest.NewSearchConfig(query, topK, metric.CosineMetric(), similarity)
+			searchConf, err := suggestService.NewSearchConfig(dict, query, queryOptions())
+
+			if err == suggest.ErrTopKLimit || err == suggest.ErrQueryLengthLimit {
+				fmt.Printf("%v\n>> ", err)
+				continue
+			}
 
 			if err != nil {
 				return err
@@ -76,6 +102,112 @@ var evalCmd = &cobra.Command{
 	},
 }
 
+// evalFile performs the search for each query of the input file and writes
+// the results as JSONL in the order of the queries
+func evalFile(suggestService *suggest.Service) error {
+	input, err := os.Open(inputPath)
+
+	if err != nil {
+		return fmt.Errorf("failed to open input file: %v", err)
+	}
+
+	defer input.Close()
+
+	var output io.Writer = os.Stdout
+
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %v", err)
+		}
+
+		defer file.Close()
+		output = file
+	}
+
+	writer := bufio.NewWriter(output)
+	encoder := json.NewEncoder(writer)
+	scanner := bufio.NewScanner(input)
+	queries := make([]string, 0, evalChunkSize)
+
+	flush := func() error {
+		batch := make([]suggest.BatchQuery, 0, len(queries))
+		errs := make([]error, len(queries))
+
+		for i, query := range queries {
+			searchConf, err := suggestService.NewSearchConfig(dict, query, queryOptions())
+
+			if err == suggest.ErrQueryLengthLimit {
+				errs[i] = err
+			} else if err != nil {
+				return err
+			}
+
+			batch = append(batch, suggest.BatchQuery{Dict: dict, Config: searchConf})
+		}
+
+		for i, result := range suggestService.SuggestBatch(batch, 0) {
+			if errs[i] != nil {
+				result = suggest.BatchResult{Err: errs[i]}
+			}
+
+			line := evalResult{Query: queries[i], Items: result.Items}
+
+			if line.Items == nil {
+				line.Items = []suggest.ResultItem{}
+			}
+
+			if result.Err != nil {
+				line.Error = result.Err.Error()
+			}
+
+			if err := encoder.Encode(line); err != nil {
+				return fmt.Errorf("failed to write result: %v", err)
+			}
+		}
+
+		queries = queries[:0]
+
+		return nil
+	}
+
+	for scanner.Scan() {
+		query := strings.TrimSpace(scanner.Text())
+
+		if len(query) == 0 {
+			continue
+		}
+
+		queries = append(queries, query)
+
+		if len(queries) == evalChunkSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read input file: %v", err)
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// queryOptions returns the search options given by the flags
+func queryOptions() suggest.QueryOptions {
+	return suggest.QueryOptions{
+		TopK:       topK,
+		Metric:     metricName,
+		Similarity: similarity,
+	}
+}
+
 // configureService creates and configures suggest service for the given
 // config and the dictionary
 func configureService() (*suggest.Service, error) {
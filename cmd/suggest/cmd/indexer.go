@@ -21,13 +21,15 @@ import (
 )
 
 var (
-	dict string
-	host string
+	dict   string
+	host   string
+	apiKey string
 )
 
 func init() {
 	indexCmd.Flags().StringVarP(&dict, "dict", "d", "", "reindex certain dict")
 	indexCmd.Flags().StringVarP(&host, "host", "", "", "host to send reindex request")
+	indexCmd.Flags().StringVarP(&apiKey, "api-key", "", "", "api key or signed token with the admin scope for the reindex request")
 
 	rootCmd.AddCommand(indexCmd)
 }
@@ -126,7 +128,13 @@ func indexJob(description suggest.IndexDescription) error {
 		return fmt.Errorf("failed to create a directory: %v", err)
 	}
 
-	if err = suggest.Index(directory, dict, description.GetWriterConfig(), description.GetIndexTokenizer()); err != nil {
+	tokenizer, err := description.GetIndexTokenizer()
+
+	if err != nil {
+		return fmt.Errorf("failed to create an index tokenizer: %v", err)
+	}
+
+	if err = suggest.Index(directory, dict, description.GetWriterConfig(), tokenizer); err != nil {
 		return err
 	}
 
@@ -212,7 +220,17 @@ func tryToSendReindexSignal() error {
 
 // tryToSendReindexRequest sends a http request with a reindex purpose
 func tryToSendReindexRequest() error {
-	resp, err := http.Post(host, "text/plain", nil)
+	req, err := http.NewRequest(http.MethodPost, host, nil)
+
+	if err != nil {
+		return fmt.Errorf("fail to create reindex request to %s, %s", host, err)
+	}
+
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 
 	if err != nil {
 		return fmt.Errorf("fail to send reindex request to %s, %s", host, err)
@@ -225,7 +243,7 @@ func tryToSendReindexRequest() error {
 		return fmt.Errorf("fail to read response body %s", err)
 	}
 
-	if string(body) != "OK" {
+	if resp.StatusCode != http.StatusAccepted {
 		return fmt.Errorf("something goes wrong with reindex request, %s", body)
 	}
 
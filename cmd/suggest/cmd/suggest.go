@@ -8,11 +8,17 @@ import (
 )
 
 var (
-	port string
+	port         string
+	grpcPort     string
+	disableHTTP  bool
+	batchWorkers int
 )
 
 func init() {
 	suggestCmd.Flags().StringVarP(&port, "port", "p", "8080", "listen port")
+	suggestCmd.Flags().StringVarP(&grpcPort, "grpc-port", "", "", "gRPC listen port, gRPC server is disabled if empty")
+	suggestCmd.Flags().BoolVarP(&disableHTTP, "disable-http", "", false, "disables http server")
+	suggestCmd.Flags().IntVarP(&batchWorkers, "batch-workers", "", 0, "number of workers of a batch request, the number of CPUs if 0")
 
 	rootCmd.AddCommand(suggestCmd)
 }
@@ -20,15 +26,18 @@ func init() {
 var suggestCmd = &cobra.Command{
 	Use:   "service-run -c [config path] -p [port]",
 	Short: "runs http server",
-	Long:  "runs http server with REST API",
+	Long:  "runs http server with REST API and (or) gRPC server",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.SetPrefix("suggest: ")
 		log.SetFlags(0)
 
 		config := api.AppConfig{
-			Port:       port,
-			ConfigPath: configPath,
-			PidPath:    pidPath,
+			Port:         port,
+			ConfigPath:   configPath,
+			PidPath:      pidPath,
+			GRPCPort:     grpcPort,
+			DisableHTTP:  disableHTTP,
+			BatchWorkers: batchWorkers,
 		}
 
 		app := api.NewApp(config)
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	httputil "github.com/suggest-go/suggest/internal/http"
+)
+
+var (
+	subject string
+	scopes  []string
+	ttl     time.Duration
+)
+
+func init() {
+	tokenCmd.Flags().StringVarP(&subject, "subject", "", "", "client the token is issued to")
+	tokenCmd.MarkFlagRequired("subject")
+	tokenCmd.Flags().StringSliceVarP(&scopes, "scope", "", []string{string(httputil.ScopeQuery)}, "granted scopes, query or admin")
+	tokenCmd.Flags().DurationVarP(&ttl, "ttl", "", 24*time.Hour, "lifetime of the token, the token doesn't expire if 0")
+
+	rootCmd.AddCommand(tokenCmd)
+}
+
+var tokenCmd = &cobra.Command{
+	Use:   "token -c [config path] --subject [client]",
+	Short: "issues a signed token",
+	Long:  "issues a token signed with the hmac secret of the server config",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := httputil.ReadServerConfig(configPath)
+
+		if err != nil {
+			return err
+		}
+
+		if config.Auth.HMAC == nil {
+			return errors.New("there is no hmac secret in the server config")
+		}
+
+		secret := config.Auth.HMAC.GetSecret()
+
+		if secret == "" {
+			return errors.New("hmac secret is empty")
+		}
+
+		claims := httputil.TokenClaims{Subject: subject}
+
+		for _, scope := range scopes {
+			claims.Scopes = append(claims.Scopes, httputil.Scope(scope))
+		}
+
+		if ttl > 0 {
+			claims.ExpiresAt = time.Now().Add(ttl).Unix()
+		}
+
+		token, err := httputil.SignToken([]byte(secret), claims)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(token)
+
+		return nil
+	},
+}
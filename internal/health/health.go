@@ -0,0 +1,177 @@
+// Package health provides the liveness, readiness and version probes of the services
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	httputil "github.com/suggest-go/suggest/internal/http"
+	"github.com/suggest-go/suggest/internal/version"
+)
+
+// Target is a dictionary or a language that the service should serve
+type Target struct {
+	// Name is the name of the target
+	Name string `json:"name"`
+	// Loaded tells that the target is served
+	Loaded bool `json:"loaded"`
+	// Generation is the number of times the target was loaded
+	Generation uint64 `json:"generation,omitempty"`
+	// LoadedAt is the time when the served target was loaded
+	LoadedAt *time.Time `json:"loadedAt,omitempty"`
+	// Size is the number of items of the target
+	Size int `json:"size,omitempty"`
+}
+
+// Reindex is the result of a reindex
+type Reindex struct {
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// Status is the readiness status of the service
+type Status struct {
+	Ready       bool         `json:"ready"`
+	Reason      string       `json:"reason,omitempty"`
+	Reindexing  bool         `json:"reindexing"`
+	LastReindex *Reindex     `json:"lastReindex,omitempty"`
+	Targets     []Target     `json:"targets"`
+	Version     version.Info `json:"version"`
+}
+
+// State tracks the reindexes of the service and the list of the configured targets
+type State struct {
+	sync.RWMutex
+	configured  []string
+	loaded      bool
+	reindexing  bool
+	lastReindex *Reindex
+	targets     func() []Target
+}
+
+// NewState creates a new State. The targets function returns the currently served targets
+func NewState(targets func() []Target) *State {
+	return &State{
+		targets: targets,
+	}
+}
+
+// SetConfigured sets the names of the targets the service should serve
+func (s *State) SetConfigured(names []string) {
+	s.Lock()
+	s.configured = append([]string{}, names...)
+	s.Unlock()
+}
+
+// Track runs the reindex job and records its result
+func (s *State) Track(job func() error) error {
+	s.Lock()
+	s.reindexing = true
+	s.lastReindex = &Reindex{StartedAt: time.Now()}
+	s.Unlock()
+
+	err := job()
+	finishedAt := time.Now()
+
+	s.Lock()
+	s.reindexing = false
+	s.lastReindex.FinishedAt = &finishedAt
+
+	if err != nil {
+		s.lastReindex.Error = err.Error()
+	} else {
+		s.loaded = true
+	}
+
+	s.Unlock()
+
+	return err
+}
+
+// Status returns the readiness status of the service. The service is ready when the
+// initial load has succeeded and all the configured targets are served
+func (s *State) Status() Status {
+	s.RLock()
+	configured := s.configured
+	status := Status{
+		Reindexing: s.reindexing,
+		Version:    version.Get(),
+	}
+
+	if s.lastReindex != nil {
+		lastReindex := *s.lastReindex
+		status.LastReindex = &lastReindex
+	}
+
+	loaded := s.loaded
+	s.RUnlock()
+
+	served := map[string]Target{}
+
+	for _, target := range s.targets() {
+		target.Loaded = true
+		served[target.Name] = target
+	}
+
+	status.Targets = make([]Target, 0, len(configured))
+
+	for _, name := range configured {
+		target, ok := served[name]
+
+		if !ok {
+			target = Target{Name: name}
+
+			if status.Reason == "" {
+				status.Reason = fmt.Sprintf("%s is not loaded", name)
+			}
+		}
+
+		status.Targets = append(status.Targets, target)
+	}
+
+	if !loaded {
+		status.Reason = "the initial load is not finished"
+	}
+
+	status.Ready = status.Reason == ""
+
+	return status
+}
+
+// Register adds the /healthz, /readyz and /version handlers to the given router
+func (s *State) Register(r *mux.Router) {
+	r.HandleFunc("/healthz", s.handleHealth).Methods("GET")
+	r.HandleFunc("/readyz", s.handleReady).Methods("GET")
+	r.HandleFunc("/version", s.handleVersion).Methods("GET")
+}
+
+// handleHealth replies that the process is alive
+func (s *State) handleHealth(w http.ResponseWriter, r *http.Request) {
+	httputil.WriteJSON(w, map[string]string{"status": "ok"})
+}
+
+// handleReady replies with the readiness status, 503 is returned if the service is not ready
+func (s *State) handleReady(w http.ResponseWriter, r *http.Request) {
+	status := s.Status()
+	code := http.StatusOK
+
+	if !status.Ready {
+		code = http.StatusServiceUnavailable
+	}
+
+	httputil.WriteJSONWithCode(w, code, status)
+}
+
+// handleVersion replies with the build information and the versions of the served targets
+func (s *State) handleVersion(w http.ResponseWriter, r *http.Request) {
+	status := s.Status()
+
+	httputil.WriteJSON(w, struct {
+		version.Info
+		Targets []Target `json:"targets"`
+	}{status.Version, status.Targets})
+}
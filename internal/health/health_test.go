@@ -0,0 +1,96 @@
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestStateStatus(t *testing.T) {
+	served := []Target{{Name: "cars", Size: 10}}
+	state := NewState(func() []Target {
+		return served
+	})
+
+	state.SetConfigured([]string{"cars"})
+
+	if status := state.Status(); status.Ready || status.Reason != "the initial load is not finished" {
+		t.Errorf("Test fail, expected not ready before the initial load, got %v", status)
+	}
+
+	if err := state.Track(func() error { return nil }); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	status := state.Status()
+
+	if !status.Ready || status.Reindexing || status.LastReindex == nil || status.LastReindex.Error != "" {
+		t.Errorf("Test fail, expected ready after the initial load, got %v", status)
+	}
+
+	if len(status.Targets) != 1 || !status.Targets[0].Loaded || status.Targets[0].Size != 10 {
+		t.Errorf("Test fail, expected the served target, got %v", status.Targets)
+	}
+
+	// a failed reindex of a new dictionary keeps the old set served and configured
+	err := state.Track(func() error {
+		if status := state.Status(); !status.Ready || !status.Reindexing {
+			t.Errorf("Test fail, expected ready while reindexing, got %v", status)
+		}
+
+		return errors.New("failed")
+	})
+
+	if err == nil {
+		t.Errorf("Test fail, expected error of the reindex")
+	}
+
+	status = state.Status()
+
+	if !status.Ready || status.LastReindex == nil || status.LastReindex.Error != "failed" {
+		t.Errorf("Test fail, expected ready with the failed reindex, got %v", status)
+	}
+
+	state.SetConfigured([]string{"cars", "books"})
+	status = state.Status()
+
+	if status.Ready || status.Reason != "books is not loaded" {
+		t.Errorf("Test fail, expected not ready without books, got %v", status)
+	}
+
+	if len(status.Targets) != 2 || status.Targets[1].Loaded {
+		t.Errorf("Test fail, expected not loaded books, got %v", status.Targets)
+	}
+}
+
+func TestStateReady(t *testing.T) {
+	state := NewState(func() []Target {
+		return nil
+	})
+
+	router := mux.NewRouter()
+	state.Register(router)
+
+	cases := []struct {
+		configured []string
+		code       int
+	}{
+		{[]string{"cars"}, http.StatusServiceUnavailable},
+		{nil, http.StatusOK},
+	}
+
+	state.Track(func() error { return nil })
+
+	for _, c := range cases {
+		state.SetConfigured(c.configured)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("GET", "/readyz", nil))
+
+		if recorder.Code != c.code {
+			t.Errorf("Test fail, expected %v, got %v", c.code, recorder.Code)
+		}
+	}
+}
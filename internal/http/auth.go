@@ -0,0 +1,202 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Scope is a set of routes a client is allowed to access
+type Scope string
+
+const (
+	// ScopeQuery grants access to the search routes
+	ScopeQuery Scope = "query"
+	// ScopeAdmin grants access to the administrative routes, e.g. reindex
+	ScopeAdmin Scope = "admin"
+)
+
+var (
+	// ErrUnauthorized tells that the credential is missing or is not valid
+	ErrUnauthorized = errors.New("credential is missing or invalid")
+	// ErrTokenExpired tells that the signed token is expired
+	ErrTokenExpired = errors.New("token is expired")
+)
+
+// Principal is an authenticated client
+type Principal struct {
+	// ID identifies the client, it is also the key of the rate limiting
+	ID string
+	// Scopes are the scopes granted to the client
+	Scopes []Scope
+}
+
+// HasScope tells whether the scope is granted to the client
+func (p Principal) HasScope(scope Scope) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authenticator resolves the client of the given credential
+type Authenticator interface {
+	// Authenticate returns the client of the credential or ErrUnauthorized
+	Authenticate(credential string) (Principal, error)
+}
+
+// Authenticators tries each of the authenticators in order
+type Authenticators []Authenticator
+
+// Authenticate returns the client resolved by the first authenticator that accepts the credential
+func (a Authenticators) Authenticate(credential string) (Principal, error) {
+	err := ErrUnauthorized
+
+	for _, authenticator := range a {
+		principal, authErr := authenticator.Authenticate(credential)
+
+		if authErr == nil {
+			return principal, nil
+		}
+
+		if authErr != ErrUnauthorized {
+			err = authErr
+		}
+	}
+
+	return Principal{}, err
+}
+
+// keyAuthenticator authenticates the clients by the static API keys
+type keyAuthenticator struct {
+	digests    [][]byte
+	principals []Principal
+}
+
+// NewKeyAuthenticator creates an Authenticator of the given static API keys
+func NewKeyAuthenticator(keys []APIKeyConfig) Authenticator {
+	a := &keyAuthenticator{}
+
+	for _, key := range keys {
+		digest := sha256.Sum256([]byte(key.Key))
+		a.digests = append(a.digests, digest[:])
+		a.principals = append(a.principals, Principal{
+			ID:     "key:" + key.ID,
+			Scopes: key.Scopes,
+		})
+	}
+
+	return a
+}
+
+// Authenticate returns the client of the given API key. All the keys are compared
+// in constant time to not leak the matched one
+func (a *keyAuthenticator) Authenticate(credential string) (Principal, error) {
+	digest := sha256.Sum256([]byte(credential))
+	found := -1
+
+	for i, d := range a.digests {
+		if subtle.ConstantTimeCompare(d, digest[:]) == 1 {
+			found = i
+		}
+	}
+
+	if found < 0 {
+		return Principal{}, ErrUnauthorized
+	}
+
+	return a.principals[found], nil
+}
+
+// TokenClaims is the payload of a signed token
+type TokenClaims struct {
+	// Subject identifies the client
+	Subject string `json:"sub"`
+	// Scopes are the scopes granted to the client
+	Scopes []Scope `json:"scopes"`
+	// ExpiresAt is the unix time of the token expiration, the token doesn't expire if it is 0
+	ExpiresAt int64 `json:"exp,omitempty"`
+}
+
+// hmacAuthenticator authenticates the clients by the HMAC-SHA256 signed tokens
+type hmacAuthenticator struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewHMACAuthenticator creates an Authenticator of the tokens signed by SignToken with the given secret
+func NewHMACAuthenticator(secret []byte) Authenticator {
+	return &hmacAuthenticator{
+		secret: secret,
+		now:    time.Now,
+	}
+}
+
+// Authenticate verifies the signature and the expiration of the given token
+func (a *hmacAuthenticator) Authenticate(credential string) (Principal, error) {
+	parts := strings.Split(credential, ".")
+
+	if len(parts) != 2 {
+		return Principal{}, ErrUnauthorized
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+
+	if err != nil {
+		return Principal{}, ErrUnauthorized
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+
+	if err != nil || !hmac.Equal(signature, sign(a.secret, payload)) {
+		return Principal{}, ErrUnauthorized
+	}
+
+	claims := TokenClaims{}
+
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Subject == "" {
+		return Principal{}, ErrUnauthorized
+	}
+
+	if claims.ExpiresAt != 0 && a.now().Unix() >= claims.ExpiresAt {
+		return Principal{}, ErrTokenExpired
+	}
+
+	return Principal{
+		ID:     "token:" + claims.Subject,
+		Scopes: claims.Scopes,
+	}, nil
+}
+
+// SignToken returns the token of the given claims signed with the secret
+func SignToken(secret []byte, claims TokenClaims) (string, error) {
+	if claims.Subject == "" {
+		return "", errors.New("subject of the token is required")
+	}
+
+	payload, err := json.Marshal(claims)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal claims: %v", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(sign(secret, payload)), nil
+}
+
+// sign returns the HMAC-SHA256 signature of the payload
+func sign(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
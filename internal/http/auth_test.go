@@ -0,0 +1,140 @@
+package http
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKeyAuthenticator(t *testing.T) {
+	authenticator := NewKeyAuthenticator([]APIKeyConfig{
+		{ID: "first", Key: "first-key", Scopes: []Scope{ScopeQuery}},
+		{ID: "second", Key: "second-key", Scopes: []Scope{ScopeQuery, ScopeAdmin}},
+	})
+
+	cases := []struct {
+		credential string
+		id         string
+		err        error
+	}{
+		{"first-key", "key:first", nil},
+		{"second-key", "key:second", nil},
+		{"", "", ErrUnauthorized},
+		{"wrong-key", "", ErrUnauthorized},
+		{"first-key ", "", ErrUnauthorized},
+	}
+
+	for _, c := range cases {
+		principal, err := authenticator.Authenticate(c.credential)
+
+		if err != c.err {
+			t.Errorf("Test fail, expected error %v, got %v", c.err, err)
+		}
+
+		if principal.ID != c.id {
+			t.Errorf("Test fail, expected %v, got %v", c.id, principal.ID)
+		}
+	}
+}
+
+func TestHMACAuthenticator(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1000, 0)
+	authenticator := &hmacAuthenticator{
+		secret: secret,
+		now:    func() time.Time { return now },
+	}
+
+	valid := signToken(t, secret, TokenClaims{Subject: "client", Scopes: []Scope{ScopeQuery}, ExpiresAt: 2000})
+	expired := signToken(t, secret, TokenClaims{Subject: "client", ExpiresAt: 1000})
+	forged := signToken(t, []byte("other"), TokenClaims{Subject: "client"})
+	payload := signToken(t, secret, TokenClaims{Subject: "client"})
+	tampered := strings.Split(signToken(t, secret, TokenClaims{Subject: "admin"}), ".")[0] + "." +
+		strings.Split(payload, ".")[1]
+
+	cases := []struct {
+		name       string
+		credential string
+		id         string
+		err        error
+	}{
+		{"valid", valid, "token:client", nil},
+		{"without expiration", payload, "token:client", nil},
+		{"expired", expired, "", ErrTokenExpired},
+		{"bad signature", forged, "", ErrUnauthorized},
+		{"tampered payload", tampered, "", ErrUnauthorized},
+		{"malformed", "token", "", ErrUnauthorized},
+		{"missing", "", "", ErrUnauthorized},
+	}
+
+	for _, c := range cases {
+		principal, err := authenticator.Authenticate(c.credential)
+
+		if err != c.err {
+			t.Errorf("Test fail of %s, expected error %v, got %v", c.name, c.err, err)
+		}
+
+		if principal.ID != c.id {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.id, principal.ID)
+		}
+	}
+}
+
+func TestGuardAuthenticate(t *testing.T) {
+	secret := "secret"
+	guard, err := NewGuard(ServerConfig{
+		Auth: AuthConfig{
+			APIKeys: []APIKeyConfig{{ID: "query", Key: "query-key", Scopes: []Scope{ScopeQuery}}},
+			HMAC:    &HMACConfig{Secret: secret},
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queryToken := signToken(t, []byte(secret), TokenClaims{Subject: "client", Scopes: []Scope{ScopeQuery}})
+	adminToken := signToken(t, []byte(secret), TokenClaims{Subject: "client", Scopes: []Scope{ScopeAdmin}})
+
+	cases := []struct {
+		name       string
+		credential string
+		scope      Scope
+		id         string
+		forbidden  bool
+		err        error
+	}{
+		{"api key", "query-key", ScopeQuery, "key:query", false, nil},
+		{"api key without scope", "query-key", ScopeAdmin, "", true, nil},
+		{"token", queryToken, ScopeQuery, "token:client", false, nil},
+		{"token without scope", queryToken, ScopeAdmin, "", true, nil},
+		{"admin token", adminToken, ScopeAdmin, "token:client", false, nil},
+		{"missing", "", ScopeQuery, "", false, ErrUnauthorized},
+		{"wrong key", "wrong-key", ScopeQuery, "", false, ErrUnauthorized},
+	}
+
+	for _, c := range cases {
+		id, err := guard.Authenticate(c.credential, "127.0.0.1", c.scope)
+
+		if _, ok := err.(*ForbiddenError); ok != c.forbidden {
+			t.Errorf("Test fail of %s, expected forbidden %v, got %v", c.name, c.forbidden, err)
+		} else if !c.forbidden && err != c.err {
+			t.Errorf("Test fail of %s, expected error %v, got %v", c.name, c.err, err)
+		}
+
+		if id != c.id {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.id, id)
+		}
+	}
+}
+
+// signToken returns the token of the claims signed with the secret
+func signToken(t *testing.T, secret []byte, claims TokenClaims) string {
+	token, err := SignToken(secret, claims)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
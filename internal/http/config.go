@@ -0,0 +1,179 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// ServerConfig holds the security settings of the http server
+type ServerConfig struct {
+	// Auth is the authentication of the clients, the routes are open if it has no credentials
+	Auth AuthConfig `json:"auth"`
+	// RateLimit is the default rate limit of a client, the requests are not limited if it is nil
+	RateLimit *RateLimitConfig `json:"rateLimit"`
+	// CORS is the cross-origin resource sharing policy
+	CORS CORSConfig `json:"cors"`
+	// TrustProxyHeaders tells to take the client address from the X-Forwarded-For header
+	TrustProxyHeaders bool `json:"trustProxyHeaders"`
+	// Listen is the listener and the limits of the http server
+	Listen ListenConfig `json:"listen"`
+}
+
+// ListenConfig describes the listener and the limits of the http server
+type ListenConfig struct {
+	// Host is the bind address of the http and the gRPC servers, 0.0.0.0 by default
+	Host string `json:"host"`
+	// UnixSocket is the path of the unix socket of the http server to listen instead of the tcp port
+	UnixSocket string `json:"unixSocket"`
+	// TLS enables https (and HTTP/2) and the TLS of the gRPC server with the given certificate
+	TLS *TLSConfig `json:"tls"`
+	// H2C enables HTTP/2 without TLS, e.g. for a sidecar proxy
+	H2C bool `json:"h2c"`
+	// ReadTimeout is the max duration of reading a request, 15s by default
+	ReadTimeout Duration `json:"readTimeout"`
+	// ReadHeaderTimeout is the max duration of reading the request headers, ReadTimeout by default
+	ReadHeaderTimeout Duration `json:"readHeaderTimeout"`
+	// WriteTimeout is the max duration of writing a response, 15s by default
+	WriteTimeout Duration `json:"writeTimeout"`
+	// IdleTimeout is the max duration of an idle keep-alive connection, 60s by default
+	IdleTimeout Duration `json:"idleTimeout"`
+	// MaxHeaderBytes is the max size of the request headers, 1MB by default
+	MaxHeaderBytes int `json:"maxHeaderBytes"`
+	// MaxBodyBytes is the max size of the request body, 1MB by default
+	MaxBodyBytes int64 `json:"maxBodyBytes"`
+	// ShutdownTimeout is the deadline of draining the active requests on shutdown, 30s by default
+	ShutdownTimeout Duration `json:"shutdownTimeout"`
+}
+
+// GetHost returns the bind address
+func (c ListenConfig) GetHost() string {
+	if c.Host == "" {
+		return defaultHost
+	}
+
+	return c.Host
+}
+
+// GetShutdownTimeout returns the deadline of draining the active requests on shutdown
+func (c ListenConfig) GetShutdownTimeout() time.Duration {
+	return durationOrDefault(c.ShutdownTimeout, defaultShutdownTimeout)
+}
+
+// GetMaxBodyBytes returns the max size of the request body
+func (c ListenConfig) GetMaxBodyBytes() int64 {
+	if c.MaxBodyBytes <= 0 {
+		return defaultMaxBodyBytes
+	}
+
+	return c.MaxBodyBytes
+}
+
+// TLSConfig describes the certificate of the server and the authentication of the client certificates
+type TLSConfig struct {
+	// CertFile is the path of the PEM certificate, it can contain the intermediate certificates
+	CertFile string `json:"certFile"`
+	// KeyFile is the path of the PEM private key
+	KeyFile string `json:"keyFile"`
+	// ClientCAFile is the path of the PEM CA certificates that verify the client certificates
+	ClientCAFile string `json:"clientCAFile"`
+	// RequireClientCert tells to reject the clients without a valid certificate
+	RequireClientCert bool `json:"requireClientCert"`
+}
+
+// Duration is a time.Duration that is read from a JSON string, e.g. "15s"
+type Duration time.Duration
+
+// UnmarshalJSON parses the duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration should be a string, e.g. \"15s\": %v", err)
+	}
+
+	duration, err := time.ParseDuration(value)
+
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(duration)
+
+	return nil
+}
+
+// MarshalJSON formats the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// AuthConfig describes the credentials accepted by the server
+type AuthConfig struct {
+	// APIKeys are the static API keys
+	APIKeys []APIKeyConfig `json:"apiKeys"`
+	// HMAC is the secret of the signed tokens
+	HMAC *HMACConfig `json:"hmac"`
+}
+
+// APIKeyConfig is a static API key of a client
+type APIKeyConfig struct {
+	// ID identifies the client in the logs and the rate limiting
+	ID string `json:"id"`
+	// Key is the API key
+	Key string `json:"key"`
+	// Scopes are the scopes granted to the client
+	Scopes []Scope `json:"scopes"`
+	// RateLimit overrides the default rate limit for the client
+	RateLimit *RateLimitConfig `json:"rateLimit"`
+}
+
+// HMACConfig is the secret of the signed tokens
+type HMACConfig struct {
+	// Secret is the signing secret
+	Secret string `json:"secret"`
+	// SecretEnv is the name of the environment variable with the secret, it is used if Secret is empty
+	SecretEnv string `json:"secretEnv"`
+}
+
+// GetSecret returns the signing secret
+func (c *HMACConfig) GetSecret() string {
+	if c.Secret == "" && c.SecretEnv != "" {
+		return os.Getenv(c.SecretEnv)
+	}
+
+	return c.Secret
+}
+
+// CORSConfig is the cross-origin resource sharing policy
+type CORSConfig struct {
+	// AllowedOrigins are the allowed origins, any origin is allowed if it is empty
+	AllowedOrigins []string `json:"allowedOrigins"`
+}
+
+// ReadServerConfig reads the "server" section of the service config file. The config file
+// can also be a plain list of the dictionaries (languages), in this case the default config is returned
+func ReadServerConfig(configPath string) (ServerConfig, error) {
+	data, err := ioutil.ReadFile(configPath)
+
+	if err != nil {
+		return ServerConfig{}, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return ServerConfig{}, nil
+	}
+
+	var config struct {
+		Server ServerConfig `json:"server"`
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return ServerConfig{}, fmt.Errorf("failed to unmarshal server config: %v", err)
+	}
+
+	return config.Server, nil
+}
@@ -0,0 +1,275 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+)
+
+// Guard authenticates the clients and limits the rate of their requests
+type Guard struct {
+	authenticator Authenticator
+	limiter       *RateLimiter
+	limit         *RateLimitConfig
+	keyLimits     map[string]RateLimitConfig
+	config        ServerConfig
+}
+
+// NewGuard creates a new Guard of the given config
+func NewGuard(config ServerConfig) (*Guard, error) {
+	g := &Guard{
+		keyLimits: make(map[string]RateLimitConfig),
+		config:    config,
+	}
+
+	authenticators := Authenticators{}
+
+	if len(config.Auth.APIKeys) > 0 {
+		seen := map[string]bool{}
+
+		for _, key := range config.Auth.APIKeys {
+			if err := validateAPIKey(key); err != nil {
+				return nil, err
+			}
+
+			if seen[key.ID] {
+				return nil, fmt.Errorf("api key %s is declared twice", key.ID)
+			}
+
+			seen[key.ID] = true
+
+			if key.RateLimit != nil {
+				limit, err := normalizeRateLimit(*key.RateLimit)
+
+				if err != nil {
+					return nil, fmt.Errorf("invalid rate limit of the key %s: %v", key.ID, err)
+				}
+
+				g.keyLimits["key:"+key.ID] = limit
+			}
+		}
+
+		authenticators = append(authenticators, NewKeyAuthenticator(config.Auth.APIKeys))
+	}
+
+	if config.Auth.HMAC != nil {
+		secret := config.Auth.HMAC.GetSecret()
+
+		if secret == "" {
+			return nil, errors.New("hmac secret is empty")
+		}
+
+		authenticators = append(authenticators, NewHMACAuthenticator([]byte(secret)))
+	}
+
+	if len(authenticators) > 0 {
+		g.authenticator = authenticators
+	}
+
+	if config.RateLimit != nil || len(g.keyLimits) > 0 {
+		g.limiter = NewRateLimiter()
+	}
+
+	if config.RateLimit != nil {
+		limit, err := normalizeRateLimit(*config.RateLimit)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit: %v", err)
+		}
+
+		g.limit = &limit
+	}
+
+	return g, nil
+}
+
+// ForbiddenError tells that the client is not granted the required scope
+type ForbiddenError struct {
+	Scope Scope
+}
+
+// Error returns the description of the error
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("scope %s is required", e.Scope)
+}
+
+// RateLimitError tells that the client exceeded its rate limit
+type RateLimitError struct {
+	// RetryAfter is the time until the next token of the client
+	RetryAfter time.Duration
+}
+
+// Error returns the description of the error
+func (e *RateLimitError) Error() string {
+	return "rate limit is exceeded"
+}
+
+// RetryAfterSeconds returns the time until the next token rounded up to seconds
+func (e *RateLimitError) RetryAfterSeconds() string {
+	return strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// Authenticate returns the ID of the client of the credential that is granted the scope. If the auth
+// is disabled, the client is identified by its address. ErrUnauthorized, ErrTokenExpired or
+// *ForbiddenError is returned for the rejected client
+func (g *Guard) Authenticate(credential, addr string, scope Scope) (string, error) {
+	if g.authenticator == nil {
+		return "ip:" + addr, nil
+	}
+
+	principal, err := g.authenticator.Authenticate(credential)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !principal.HasScope(scope) {
+		return "", &ForbiddenError{Scope: scope}
+	}
+
+	return principal.ID, nil
+}
+
+// Allow takes a token of the client, *RateLimitError is returned if the client exceeded its rate limit
+func (g *Guard) Allow(clientID string) error {
+	if g.limiter == nil {
+		return nil
+	}
+
+	limit, ok := g.keyLimits[clientID]
+
+	if !ok {
+		if g.limit == nil {
+			return nil
+		}
+
+		limit = *g.limit
+	}
+
+	if allowed, retryAfter := g.limiter.Allow(clientID, limit); !allowed {
+		return &RateLimitError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// Require returns a middleware that authenticates the client, checks that it is granted the scope
+// and limits the rate of its requests
+func (g *Guard) Require(scope Scope) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientID, err := g.Authenticate(credential(r), clientIP(r), scope)
+
+			if err == nil {
+				err = g.Allow(clientID)
+			}
+
+			switch e := err.(type) {
+			case nil:
+				next.ServeHTTP(w, r)
+			case *ForbiddenError:
+				WriteError(w, http.StatusForbidden, e.Error())
+			case *RateLimitError:
+				w.Header().Set("Retry-After", e.RetryAfterSeconds())
+				WriteError(w, http.StatusTooManyRequests, e.Error())
+			default:
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				WriteError(w, http.StatusUnauthorized, e.Error())
+			}
+		})
+	}
+}
+
+// Handler wraps the router with the CORS policy and, if it is enabled, with the proxy headers handling
+func (g *Guard) Handler(h http.Handler) http.Handler {
+	origins := g.config.CORS.AllowedOrigins
+
+	if len(origins) == 0 {
+		origins = []string{"*"}
+	}
+
+	h = handlers.CORS(
+		handlers.AllowedOrigins(origins),
+		handlers.AllowedMethods([]string{"GET", "POST"}),
+		handlers.AllowedHeaders([]string{"Authorization", "Content-Type", "X-API-Key"}),
+	)(h)
+
+	if g.config.TrustProxyHeaders {
+		h = handlers.ProxyHeaders(h)
+	}
+
+	return h
+}
+
+// credential returns the API key or the bearer token of the request
+func credential(r *http.Request) string {
+	return Credential(r.Header.Get("X-API-Key"), r.Header.Get("Authorization"))
+}
+
+// Credential returns the API key or, if it is empty, the token of the bearer authorization
+func Credential(apiKey, authorization string) string {
+	if apiKey != "" {
+		return apiKey
+	}
+
+	if len(authorization) > len("Bearer ") && strings.EqualFold(authorization[:len("Bearer ")], "Bearer ") {
+		return authorization[len("Bearer "):]
+	}
+
+	return ""
+}
+
+// clientIP returns the address of the client without the port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// validateAPIKey checks that the key has an id, a value and the known scopes
+func validateAPIKey(key APIKeyConfig) error {
+	if key.ID == "" || key.Key == "" {
+		return errors.New("id and key should be declared for each api key")
+	}
+
+	if len(key.Scopes) == 0 {
+		return fmt.Errorf("api key %s has no scopes", key.ID)
+	}
+
+	for _, scope := range key.Scopes {
+		if scope != ScopeQuery && scope != ScopeAdmin {
+			return fmt.Errorf("unknown scope %s of the api key %s", scope, key.ID)
+		}
+	}
+
+	return nil
+}
+
+// normalizeRateLimit validates the rate limit and sets the default burst
+func normalizeRateLimit(limit RateLimitConfig) (RateLimitConfig, error) {
+	if limit.Rate <= 0 {
+		return RateLimitConfig{}, errors.New("rate should be positive")
+	}
+
+	if limit.Burst < 0 {
+		return RateLimitConfig{}, errors.New("burst should not be negative")
+	}
+
+	if limit.Burst == 0 {
+		limit.Burst = int(math.Ceil(limit.Rate))
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestGuardRequire(t *testing.T) {
+	guard, err := NewGuard(ServerConfig{
+		Auth: AuthConfig{
+			APIKeys: []APIKeyConfig{
+				{ID: "query", Key: "query-key", Scopes: []Scope{ScopeQuery}},
+				{
+					ID:        "limited",
+					Key:       "limited-key",
+					Scopes:    []Scope{ScopeQuery},
+					RateLimit: &RateLimitConfig{Rate: 0.5, Burst: 1},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := newGuardedHandler(guard)
+
+	cases := []struct {
+		name       string
+		path       string
+		header     string
+		value      string
+		status     int
+		retryAfter string
+	}{
+		{"api key", "/query", "X-API-Key", "query-key", http.StatusOK, ""},
+		{"bearer", "/query", "Authorization", "Bearer query-key", http.StatusOK, ""},
+		{"missing key", "/query", "", "", http.StatusUnauthorized, ""},
+		{"wrong key", "/query", "X-API-Key", "wrong-key", http.StatusUnauthorized, ""},
+		{"missing scope", "/admin", "X-API-Key", "query-key", http.StatusForbidden, ""},
+		{"first request", "/query", "X-API-Key", "limited-key", http.StatusOK, ""},
+		{"rate limited", "/query", "X-API-Key", "limited-key", http.StatusTooManyRequests, "2"},
+	}
+
+	for _, c := range cases {
+		r := httptest.NewRequest(http.MethodGet, c.path, nil)
+
+		if c.header != "" {
+			r.Header.Set(c.header, c.value)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != c.status {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.status, w.Code)
+		}
+
+		if actual := w.Header().Get("Retry-After"); actual != c.retryAfter {
+			t.Errorf("Test fail of %s, expected Retry-After %q, got %q", c.name, c.retryAfter, actual)
+		}
+	}
+}
+
+func TestGuardTrustProxyHeaders(t *testing.T) {
+	cases := []struct {
+		trust  bool
+		status int
+	}{
+		{true, http.StatusOK},
+		{false, http.StatusTooManyRequests},
+	}
+
+	for _, c := range cases {
+		guard, err := NewGuard(ServerConfig{
+			RateLimit:         &RateLimitConfig{Rate: 0.001, Burst: 1},
+			TrustProxyHeaders: c.trust,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		handler := newGuardedHandler(guard)
+		status := 0
+
+		// both clients come through the same proxy, they are limited apart only if its headers are trusted
+		for _, client := range []string{"10.0.0.1", "10.0.0.2"} {
+			r := httptest.NewRequest(http.MethodGet, "/query", nil)
+			r.RemoteAddr = "192.168.0.1:4000"
+			r.Header.Set("X-Forwarded-For", client)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			status = w.Code
+		}
+
+		if status != c.status {
+			t.Errorf("Test fail of trust %v, expected %v, got %v", c.trust, c.status, status)
+		}
+	}
+}
+
+// newGuardedHandler returns the router with the query and the admin routes protected by the guard
+func newGuardedHandler(guard *Guard) http.Handler {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	router := mux.NewRouter()
+	router.Handle("/query", guard.Require(ScopeQuery)(ok))
+	router.Handle("/admin", guard.Require(ScopeAdmin)(ok))
+
+	return guard.Handler(router)
+}
@@ -2,47 +2,211 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
+	"os"
 	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const (
+	defaultHost            = "0.0.0.0"
+	defaultReadTimeout     = 15 * time.Second
+	defaultWriteTimeout    = 15 * time.Second
+	defaultIdleTimeout     = 60 * time.Second
+	defaultShutdownTimeout = 30 * time.Second
+	defaultMaxBodyBytes    = 1 << 20
 )
 
+// Server is the http server shared by the services
 type Server struct {
-	r    http.Handler
-	addr string
+	r      http.Handler
+	port   string
+	config ListenConfig
 }
 
-// NewServer creates new instance of HttpServer
-func NewServer(r http.Handler, addr string) *Server {
+// NewServer creates new instance of HttpServer that listens the given port with the given config
+func NewServer(r http.Handler, port string, config ListenConfig) *Server {
 	return &Server{
-		r:    r,
-		addr: addr,
+		r:      r,
+		port:   port,
+		config: config,
 	}
 }
 
-// Run starts serving http requests
+// Run starts serving http requests. When the context is done, the server stops accepting
+// new connections and waits for the active requests until the shutdown timeout
 func (h *Server) Run(ctx context.Context) error {
-	srv := &http.Server{
-		Addr:         h.addr,
-		WriteTimeout: time.Second * 15,
-		ReadTimeout:  time.Second * 15,
-		IdleTimeout:  time.Second * 60,
-		Handler:      h.r,
+	srv, err := h.newHTTPServer()
+
+	if err != nil {
+		return err
 	}
 
+	listener, err := h.listen()
+
+	if err != nil {
+		return err
+	}
+
+	shutdown := make(chan error, 1)
+
 	go func() {
 		<-ctx.Done()
+		shutdown <- h.shutdown(srv)
+	}()
+
+	if h.config.TLS != nil {
+		// the certificate is loaded by NewTLSConfig
+		err = srv.ServeTLS(listener, "", "")
+	} else {
+		err = srv.Serve(listener)
+	}
+
+	if err != http.ErrServerClosed {
+		return err
+	}
+
+	if err := <-shutdown; err != nil {
+		return err
+	}
+
+	log.Println("Server was shutdown gracefully")
 
-		if err := srv.Shutdown(context.Background()); err != nil {
-			log.Fatalf("Fail to shutdown server %s", err)
+	return nil
+}
+
+// newHTTPServer creates the http server with the configured limits and TLS
+func (h *Server) newHTTPServer() (*http.Server, error) {
+	handler := h.r
+
+	if h.config.H2C && h.config.TLS == nil {
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadTimeout:       durationOrDefault(h.config.ReadTimeout, defaultReadTimeout),
+		ReadHeaderTimeout: time.Duration(h.config.ReadHeaderTimeout),
+		WriteTimeout:      durationOrDefault(h.config.WriteTimeout, defaultWriteTimeout),
+		IdleTimeout:       durationOrDefault(h.config.IdleTimeout, defaultIdleTimeout),
+		MaxHeaderBytes:    h.config.MaxHeaderBytes,
+	}
+
+	if h.config.TLS != nil {
+		tlsConfig, err := NewTLSConfig(*h.config.TLS)
+
+		if err != nil {
+			return nil, err
+		}
+
+		srv.TLSConfig = tlsConfig
+	}
+
+	return srv, nil
+}
+
+// listen opens the unix socket or the tcp port
+func (h *Server) listen() (net.Listener, error) {
+	if h.config.UnixSocket != "" {
+		// the socket file is left by a previous process that was not stopped gracefully,
+		// any other file at the path is kept untouched
+		info, err := os.Lstat(h.config.UnixSocket)
+
+		if err == nil && info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a unix socket", h.config.UnixSocket)
 		}
-	}()
 
-	err := srv.ListenAndServe()
-	if err == http.ErrServerClosed {
-		log.Println("Server was shutdown gracefully")
-		return nil
+		if err == nil {
+			if err := os.Remove(h.config.UnixSocket); err != nil {
+				return nil, fmt.Errorf("failed to remove stale unix socket: %v", err)
+			}
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to stat unix socket: %v", err)
+		}
+
+		return net.Listen("unix", h.config.UnixSocket)
+	}
+
+	return net.Listen("tcp", net.JoinHostPort(h.config.GetHost(), h.port))
+}
+
+// shutdown waits for the active requests until the shutdown timeout and closes the rest connections
+func (h *Server) shutdown(srv *http.Server) error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.config.GetShutdownTimeout())
+	defer cancel()
+
+	err := srv.Shutdown(ctx)
+
+	if err == context.DeadlineExceeded {
+		log.Println("Shutdown timeout is exceeded, the active connections are closed")
+		return srv.Close()
 	}
 
 	return err
 }
+
+// NewTLSConfig creates the TLS config with the certificate of the server that verifies
+// the client certificates, if the client CA is given
+func NewTLSConfig(config TLSConfig) (*tls.Config, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("certFile and keyFile should be declared for TLS")
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %v", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if config.ClientCAFile == "" {
+		if config.RequireClientCert {
+			return nil, errors.New("clientCAFile should be declared to require client certificates")
+		}
+
+		return tlsConfig, nil
+	}
+
+	data, err := ioutil.ReadFile(config.ClientCAFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("there are no certificates in %s", config.ClientCAFile)
+	}
+
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+
+	if config.RequireClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// durationOrDefault returns the duration or the default value if it is not set
+func durationOrDefault(d Duration, defaultVal time.Duration) time.Duration {
+	if d == 0 {
+		return defaultVal
+	}
+
+	return time.Duration(d)
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestServerUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "http")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "server.sock")
+
+	// a stale socket of a previous process
+	stale, err := net.Listen("unix", socket)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, "pong")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- NewServer(handler, "", ListenConfig{UnixSocket: socket}).Run(ctx)
+	}()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+
+	var resp *http.Response
+
+	for i := 0; i < 100; i++ {
+		if resp, err = client.Get("http://unix/ping"); err == nil {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || string(body) != `"pong"` {
+		t.Errorf("Test fail, expected pong, got %v %q", resp.StatusCode, body)
+	}
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("Test fail, expected graceful stop, got %v", err)
+	}
+}
+
+func TestServerUnixSocketKeepsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "http")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "data.txt")
+
+	if err := ioutil.WriteFile(path, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewServer(http.NotFoundHandler(), "", ListenConfig{UnixSocket: path}).Run(context.Background())
+
+	if err == nil {
+		t.Errorf("Test fail, expected error of the regular file")
+	}
+
+	if data, err := ioutil.ReadFile(path); err != nil || string(data) != "data" {
+		t.Errorf("Test fail, expected the file to be kept, got %q %v", data, err)
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeCertificate(t, dir)
+	emptyFile := filepath.Join(dir, "empty.pem")
+
+	if err := ioutil.WriteFile(emptyFile, []byte("no certificates"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		config     TLSConfig
+		isError    bool
+		clientAuth tls.ClientAuthType
+	}{
+		{"server certificate", TLSConfig{CertFile: certFile, KeyFile: keyFile}, false, tls.NoClientCert},
+		{
+			"optional client certificate",
+			TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile},
+			false,
+			tls.VerifyClientCertIfGiven,
+		},
+		{
+			"required client certificate",
+			TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile, RequireClientCert: true},
+			false,
+			tls.RequireAndVerifyClientCert,
+		},
+		{"missing key", TLSConfig{CertFile: certFile}, true, 0},
+		{"missing certificate", TLSConfig{KeyFile: keyFile}, true, 0},
+		{"missing files", TLSConfig{CertFile: "missing.pem", KeyFile: "missing.key"}, true, 0},
+		{"mismatched key", TLSConfig{CertFile: keyFile, KeyFile: certFile}, true, 0},
+		{"required client certificate without CA", TLSConfig{CertFile: certFile, KeyFile: keyFile, RequireClientCert: true}, true, 0},
+		{"missing client CA", TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: "missing.pem"}, true, 0},
+		{"empty client CA", TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: emptyFile}, true, 0},
+	}
+
+	for _, c := range cases {
+		config, err := NewTLSConfig(c.config)
+
+		if (err != nil) != c.isError {
+			t.Errorf("Test fail of %s, expected error %v, got %v", c.name, c.isError, err)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if config.ClientAuth != c.clientAuth {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.clientAuth, config.ClientAuth)
+		}
+
+		if len(config.Certificates) != 1 || config.MinVersion != tls.VersionTLS12 {
+			t.Errorf("Test fail of %s, expected the certificate and TLS 1.2, got %v", c.name, config)
+		}
+	}
+}
+
+// writeCertificate writes a self-signed certificate of localhost to the dir
+func writeCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
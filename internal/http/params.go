@@ -0,0 +1,161 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+var (
+	// ErrQueryRequired tells that the request has no search query
+	ErrQueryRequired = errors.New("query is required")
+	// ErrBodyTooLarge tells that the request body exceeds the limit
+	ErrBodyTooLarge = errors.New("request body is too large")
+)
+
+// LimitBody returns a middleware that limits the size of the request body, the reading of
+// the body over the limit fails with ErrBodyTooLarge
+func LimitBody(maxBytes int64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body != nil {
+				r.Body = &limitedBody{
+					ReadCloser: http.MaxBytesReader(w, r.Body, maxBytes),
+					limit:      maxBytes,
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// limitedBody replaces the error of http.MaxBytesReader with ErrBodyTooLarge
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+// Read reads the body up to the limit
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+
+	if err != nil && err != io.EOF && b.read >= b.limit {
+		err = ErrBodyTooLarge
+	}
+
+	return n, err
+}
+
+// JSONParams is a middleware that merges the fields of a JSON object body into the form
+// values of the request, so the parameters can be read by FormValue regardless of the way
+// they are passed. The body is kept intact for the next handlers
+func JSONParams(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := parseJSONParams(r); err == ErrBodyTooLarge {
+			WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		} else if err != nil {
+			WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseJSONParams merges the fields of a JSON object body into the request form
+func parseJSONParams(r *http.Request) error {
+	if r.Body == nil {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	if mediaType != "application/json" {
+		return nil
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+
+	if err == ErrBodyTooLarge {
+		return err
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read body: %v", err)
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil
+	}
+
+	params := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&params); err != nil {
+		return fmt.Errorf("failed to decode body: %v", err)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return fmt.Errorf("failed to parse form: %v", err)
+	}
+
+	for name, value := range params {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			r.Form.Set(name, v)
+		case json.Number:
+			r.Form.Set(name, v.String())
+		case bool:
+			r.Form.Set(name, strconv.FormatBool(v))
+		default:
+			return fmt.Errorf("parameter %s should be a string, a number or a boolean", name)
+		}
+	}
+
+	return nil
+}
+
+// PathValue returns the unescaped value of the named route variable
+func PathValue(r *http.Request, name string) (string, error) {
+	value, err := url.PathUnescape(mux.Vars(r)[name])
+
+	if err != nil {
+		return "", fmt.Errorf("failed to unescape %s: %v", name, err)
+	}
+
+	return value, nil
+}
+
+// QueryValue returns the search query of the request. The query is taken from the route
+// variable if the route declares it, otherwise from the query parameter
+func QueryValue(r *http.Request) (string, error) {
+	if _, ok := mux.Vars(r)["query"]; ok {
+		return PathValue(r, "query")
+	}
+
+	query := r.FormValue("query")
+
+	if _, ok := r.Form["query"]; !ok {
+		return "", ErrQueryRequired
+	}
+
+	return query, nil
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitBody(t *testing.T) {
+	handler := LimitBody(32)(JSONParams(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteJSON(w, r.FormValue("query"))
+	})))
+
+	cases := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"small body", `{"query": "foo"}`, http.StatusOK},
+		{"body of the limit", `{"query": "` + strings.Repeat("a", 32-len(`{"query": ""}`)) + `"}`, http.StatusOK},
+		{"large body", `{"query": "` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge},
+		{"invalid body", `{"query": `, http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(c.body))
+		r.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != c.status {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.status, w.Code)
+		}
+	}
+}
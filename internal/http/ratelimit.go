@@ -0,0 +1,92 @@
+package http
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is the interval of the removal of the idle buckets
+const sweepInterval = time.Minute
+
+// RateLimitConfig is the token bucket of a client
+type RateLimitConfig struct {
+	// Rate is the number of the requests per second
+	Rate float64 `json:"rate"`
+	// Burst is the max number of the requests at once, the rate rounded up by default
+	Burst int `json:"burst"`
+}
+
+// bucket is the token bucket of a client
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   RateLimitConfig
+}
+
+// refill adds the tokens accumulated since the last update
+func (b *bucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*b.limit.Rate)
+	b.updated = now
+}
+
+// RateLimiter limits the requests of each client with a token bucket
+type RateLimiter struct {
+	sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter creates a new RateLimiter
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of the client with the given limit. If the bucket is
+// empty, false and the time until the next token is returned
+func (l *RateLimiter) Allow(key string, limit RateLimitConfig) (bool, time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+
+	if !ok || b.limit != limit {
+		b = &bucket{tokens: float64(limit.Burst), updated: now, limit: limit}
+		l.buckets[key] = b
+	}
+
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	}
+
+	b.tokens--
+
+	return true, 0
+}
+
+// sweep forgets the buckets that are full, they are the same as the new ones
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range l.buckets {
+		b.refill(now)
+
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := NewRateLimiter()
+	limiter.now = func() time.Time { return now }
+	limit := RateLimitConfig{Rate: 2, Burst: 2}
+
+	cases := []struct {
+		name       string
+		advance    time.Duration
+		key        string
+		allowed    bool
+		retryAfter time.Duration
+	}{
+		{"full bucket", 0, "a", true, 0},
+		{"burst", 0, "a", true, 0},
+		{"empty bucket", 0, "a", false, 500 * time.Millisecond},
+		{"other client", 0, "b", true, 0},
+		{"partial refill", 250 * time.Millisecond, "a", false, 250 * time.Millisecond},
+		{"refilled token", 250 * time.Millisecond, "a", true, 0},
+		{"refilled token is taken", 0, "a", false, 500 * time.Millisecond},
+		{"refill is capped by burst", time.Hour, "a", true, 0},
+		{"burst after refill", 0, "a", true, 0},
+		{"empty after refill", 0, "a", false, 500 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		now = now.Add(c.advance)
+		allowed, retryAfter := limiter.Allow(c.key, limit)
+
+		if allowed != c.allowed {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.allowed, allowed)
+		}
+
+		if retryAfter != c.retryAfter {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.retryAfter, retryAfter)
+		}
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter := NewRateLimiter()
+	limiter.now = func() time.Time { return now }
+	limiter.lastSweep = now
+	limit := RateLimitConfig{Rate: 1, Burst: 1}
+
+	limiter.Allow("idle", limit)
+	now = now.Add(sweepInterval)
+	limiter.Allow("active", limit)
+
+	if _, ok := limiter.buckets["idle"]; ok {
+		t.Errorf("Test fail, expected the full bucket to be swept")
+	}
+
+	if _, ok := limiter.buckets["active"]; !ok {
+		t.Errorf("Test fail, expected the bucket of the active client to be kept")
+	}
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// ErrorResponse is the JSON envelope of an API error
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes an API error
+type ErrorBody struct {
+	// Code is the http status code
+	Code int `json:"code"`
+	// Message is the human readable description of the error
+	Message string `json:"message"`
+}
+
+// WriteJSON writes the given value as a JSON response
+func WriteJSON(w http.ResponseWriter, value interface{}) {
+	WriteJSONWithCode(w, http.StatusOK, value)
+}
+
+// WriteJSONWithCode writes the given value as a JSON response with the given status code
+func WriteJSONWithCode(w http.ResponseWriter, code int, value interface{}) {
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Fail to write response %s", err)
+	}
+}
+
+// WriteError writes the error with the given status code in the JSON envelope
+func WriteError(w http.ResponseWriter, code int, message string) {
+	data, err := json.Marshal(ErrorResponse{
+		Error: ErrorBody{
+			Code:    code,
+			Message: message,
+		},
+	})
+
+	if err != nil {
+		http.Error(w, message, code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Fail to write response %s", err)
+	}
+}
+
+// NotFoundHandler replies with the not found error in the JSON envelope
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, http.StatusNotFound, "route is not found")
+	})
+}
+
+// MethodNotAllowedHandler replies with the method not allowed error in the JSON envelope
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, http.StatusMethodNotAllowed, "method is not allowed")
+	})
+}
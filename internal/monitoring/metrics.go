@@ -0,0 +1,153 @@
+// Package monitoring provides the Prometheus metrics of the services
+package monitoring
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/suggest-go/suggest/pkg/utils"
+)
+
+const (
+	// StatusOK is the status of a successfully handled request
+	StatusOK = "ok"
+	// StatusNotFound is the status of a request to an unknown dictionary or language
+	StatusNotFound = "not_found"
+	// StatusError is the status of a failed request
+	StatusError = "error"
+	// UnknownLabel replaces the dictionary or the language of a not found request
+	// to keep the cardinality of the labels bounded
+	UnknownLabel = "unknown"
+)
+
+// Metrics is the set of the metrics of a service
+type Metrics struct {
+	namespace          string
+	registry           *prometheus.Registry
+	requests           *prometheus.CounterVec
+	latency            *prometheus.HistogramVec
+	candidates         *prometheus.HistogramVec
+	reindexDuration    prometheus.Gauge
+	reindexLastSuccess prometheus.Gauge
+	reindexFailures    prometheus.Counter
+}
+
+// New creates the metrics of the service with the given namespace. The label is the name of the
+// label that tells the target of a request, e.g. dict or lang
+func New(namespace, label string) *Metrics {
+	m := &Metrics{
+		namespace: namespace,
+		registry:  prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "The number of handled requests.",
+		}, []string{"method", label, "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "The latency of the handled requests.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"method", label}),
+		candidates: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "candidates",
+			Help:      "The number of candidates returned by the handled requests.",
+			Buckets:   []float64{0, 1, 2, 5, 10, 20, 50, 100},
+		}, []string{"method", label}),
+		reindexDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "reindex_duration_seconds",
+			Help:      "The duration of the last reindex.",
+		}),
+		reindexLastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "reindex_last_success_timestamp_seconds",
+			Help:      "The unix time of the last successful reindex.",
+		}),
+		reindexFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reindex_failures_total",
+			Help:      "The number of failed reindexes.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.latency,
+		m.candidates,
+		m.reindexDuration,
+		m.reindexLastSuccess,
+		m.reindexFailures,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "mmap_bytes",
+			Help:      "The total size of the memory mapped index files.",
+		}, func() float64 {
+			return float64(utils.MappedBytes())
+		}),
+	)
+
+	return m
+}
+
+// ObserveRequest records the handled request of the method to the target
+func (m *Metrics) ObserveRequest(method, target, status string, elapsed time.Duration, candidates int) {
+	m.requests.WithLabelValues(method, target, status).Inc()
+
+	if status != StatusOK {
+		return
+	}
+
+	m.latency.WithLabelValues(method, target).Observe(elapsed.Seconds())
+	m.candidates.WithLabelValues(method, target).Observe(float64(candidates))
+}
+
+// ObserveReindex records the reindex that was started at the given time
+func (m *Metrics) ObserveReindex(start time.Time, err error) {
+	m.reindexDuration.Set(time.Since(start).Seconds())
+
+	if err != nil {
+		m.reindexFailures.Inc()
+		return
+	}
+
+	m.reindexLastSuccess.SetToCurrentTime()
+}
+
+// RegisterSizes registers the gauge of the sizes returned by fn, the keys of the map
+// are the values of the given label
+func (m *Metrics) RegisterSizes(name, help, label string, fn func() map[string]int) {
+	m.registry.MustRegister(&sizeCollector{
+		desc: prometheus.NewDesc(prometheus.BuildFQName(m.namespace, "", name), help, []string{label}, nil),
+		fn:   fn,
+	})
+}
+
+// Handler returns the http handler that exposes the metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// sizeCollector collects the sizes returned by fn at the scrape time
+type sizeCollector struct {
+	desc *prometheus.Desc
+	fn   func() map[string]int
+}
+
+// Describe implements prometheus.Collector interface
+func (c *sizeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector interface
+func (c *sizeCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, size := range c.fn() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(size), name)
+	}
+}
@@ -0,0 +1,50 @@
+package monitoring
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New("suggest", "dict")
+	m.RegisterSizes("dictionary_size", "The size of the dictionaries.", "dict", func() map[string]int {
+		return map[string]int{"cars": 42}
+	})
+
+	m.ObserveRequest("suggest", "cars", StatusOK, time.Millisecond, 3)
+	m.ObserveRequest("suggest", UnknownLabel, StatusNotFound, time.Millisecond, 0)
+	m.ObserveReindex(time.Now(), errors.New("failed"))
+	m.ObserveReindex(time.Now(), nil)
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(recorder.Body)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`suggest_requests_total{dict="cars",method="suggest",status="ok"} 1`,
+		`suggest_requests_total{dict="unknown",method="suggest",status="not_found"} 1`,
+		`suggest_candidates_sum{dict="cars",method="suggest"} 3`,
+		`suggest_request_duration_seconds_count{dict="cars",method="suggest"} 1`,
+		`suggest_reindex_failures_total 1`,
+		`suggest_dictionary_size{dict="cars"} 42`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(string(body), line) {
+			t.Errorf("Test fail, expected %s in the metrics", line)
+		}
+	}
+
+	// the latency of a failed request is not observed
+	if strings.Contains(string(body), `suggest_request_duration_seconds_count{dict="unknown"`) {
+		t.Errorf("Test fail, expected no latency of the not found request")
+	}
+}
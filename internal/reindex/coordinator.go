@@ -0,0 +1,215 @@
+// Package reindex provides the coordinator of the reindex jobs
+package reindex
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// maxHistory is the number of finished jobs kept by the coordinator
+const maxHistory = 20
+
+// ErrJobNotFound tells that the coordinator doesn't know the requested job
+var ErrJobNotFound = errors.New("reindex job is not found")
+
+// Status is the status of a reindex job
+type Status string
+
+const (
+	// Pending means that the job waits for the previous one to finish
+	Pending Status = "pending"
+	// Running means that the job is in progress
+	Running Status = "running"
+	// Succeeded means that the job has finished successfully
+	Succeeded Status = "succeeded"
+	// Failed means that the job has finished with an error
+	Failed Status = "failed"
+)
+
+// Job describes a reindex job
+type Job struct {
+	ID         string     `json:"id"`
+	Status     Status     `json:"status"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Done is the number of the processed items, e.g. built indexes
+	Done int `json:"done"`
+	// Total is the number of the items to process, 0 if it is not known yet
+	Total int    `json:"total"`
+	Error string `json:"error,omitempty"`
+}
+
+// ProgressFunc reports that done of total items are processed
+type ProgressFunc func(done, total int)
+
+// RunFunc performs a reindex and reports its progress
+type RunFunc func(progress ProgressFunc) error
+
+// Coordinator runs the reindex jobs one by one. A job submitted while another one
+// is pending is merged into the pending one
+type Coordinator struct {
+	sync.Mutex
+	run     RunFunc
+	jobs    map[string]*Job
+	done    map[string]chan struct{}
+	history []string
+	pending string
+	queue   chan string
+}
+
+// NewCoordinator creates a new Coordinator of the given reindex
+func NewCoordinator(run RunFunc) *Coordinator {
+	return &Coordinator{
+		run:   run,
+		jobs:  make(map[string]*Job),
+		done:  make(map[string]chan struct{}),
+		queue: make(chan string, 1),
+	}
+}
+
+// Submit schedules a reindex and returns its job. If there is a pending job, it is returned instead
+func (c *Coordinator) Submit() Job {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.pending != "" {
+		return *c.jobs[c.pending]
+	}
+
+	job := &Job{
+		ID:        newJobID(),
+		Status:    Pending,
+		CreatedAt: time.Now(),
+	}
+
+	c.jobs[job.ID] = job
+	c.done[job.ID] = make(chan struct{})
+	c.history = append(c.history, job.ID)
+	c.pending = job.ID
+	c.queue <- job.ID
+	c.trim()
+
+	return *job
+}
+
+// Job returns the job with the given id
+func (c *Coordinator) Job(id string) (Job, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	job, ok := c.jobs[id]
+
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+
+	return *job, nil
+}
+
+// Jobs returns the known jobs, the latest job goes first
+func (c *Coordinator) Jobs() []Job {
+	c.Lock()
+	defer c.Unlock()
+
+	jobs := make([]Job, 0, len(c.history))
+
+	for i := len(c.history) - 1; i >= 0; i-- {
+		jobs = append(jobs, *c.jobs[c.history[i]])
+	}
+
+	return jobs
+}
+
+// Wait waits until the job with the given id is finished
+func (c *Coordinator) Wait(ctx context.Context, id string) (Job, error) {
+	c.Lock()
+	done, ok := c.done[id]
+	c.Unlock()
+
+	if !ok {
+		return Job{}, ErrJobNotFound
+	}
+
+	select {
+	case <-done:
+		return c.Job(id)
+	case <-ctx.Done():
+		return Job{}, ctx.Err()
+	}
+}
+
+// Run performs the submitted jobs until the context is done
+func (c *Coordinator) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case id := <-c.queue:
+			c.perform(id)
+		}
+	}
+}
+
+// perform runs the job with the given id and records its result
+func (c *Coordinator) perform(id string) {
+	startedAt := time.Now()
+
+	c.Lock()
+	c.pending = ""
+	job := c.jobs[id]
+	job.Status = Running
+	job.StartedAt = &startedAt
+	c.Unlock()
+
+	err := c.run(func(done, total int) {
+		c.Lock()
+		job.Done, job.Total = done, total
+		c.Unlock()
+	})
+
+	finishedAt := time.Now()
+
+	c.Lock()
+	job.FinishedAt = &finishedAt
+	job.Status = Succeeded
+
+	if err != nil {
+		job.Status = Failed
+		job.Error = err.Error()
+	}
+
+	close(c.done[id])
+	c.Unlock()
+}
+
+// trim forgets the oldest finished jobs beyond the history limit
+func (c *Coordinator) trim() {
+	for len(c.history) > maxHistory {
+		id := c.history[0]
+		status := c.jobs[id].Status
+
+		if status == Pending || status == Running {
+			return
+		}
+
+		c.history = c.history[1:]
+		delete(c.jobs, id)
+		delete(c.done, id)
+	}
+}
+
+// newJobID returns a random job id
+func newJobID() string {
+	b := make([]byte, 8)
+
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+
+	return hex.EncodeToString(b)
+}
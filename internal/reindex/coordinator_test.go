@@ -0,0 +1,195 @@
+package reindex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCoordinatorCoalescesSubmits(t *testing.T) {
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	runs := 0
+
+	coordinator := NewCoordinator(func(progress ProgressFunc) error {
+		runs++
+		started <- struct{}{}
+		<-release
+
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go coordinator.Run(ctx)
+
+	running := coordinator.Submit()
+	<-started
+
+	pending := coordinator.Submit()
+
+	if pending.ID == running.ID {
+		t.Fatalf("Test fail, expected a new job while %s is running", running.ID)
+	}
+
+	for i := 0; i < 3; i++ {
+		if job := coordinator.Submit(); job.ID != pending.ID {
+			t.Errorf("Test fail, expected %v, got %v", pending.ID, job.ID)
+		}
+	}
+
+	close(release)
+
+	for _, id := range []string{running.ID, pending.ID} {
+		job, err := coordinator.Wait(ctx, id)
+
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+
+		if job.Status != Succeeded {
+			t.Errorf("Test fail, expected %v, got %v", Succeeded, job.Status)
+		}
+	}
+
+	if runs != 2 {
+		t.Errorf("Test fail, expected %v, got %v", 2, runs)
+	}
+
+	if jobs := coordinator.Jobs(); len(jobs) != 2 || jobs[0].ID != pending.ID {
+		t.Errorf("Test fail, expected the pending job first, got %v", jobs)
+	}
+}
+
+func TestCoordinatorFailedJob(t *testing.T) {
+	coordinator := NewCoordinator(func(progress ProgressFunc) error {
+		progress(1, 3)
+
+		return errors.New("index is broken")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go coordinator.Run(ctx)
+
+	job, err := coordinator.Wait(ctx, coordinator.Submit().ID)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if job.Status != Failed || job.Error != "index is broken" {
+		t.Errorf("Test fail, expected failed job, got %v %v", job.Status, job.Error)
+	}
+
+	if job.Done != 1 || job.Total != 3 {
+		t.Errorf("Test fail, expected progress 1 of 3, got %v of %v", job.Done, job.Total)
+	}
+
+	if job.StartedAt == nil || job.FinishedAt == nil {
+		t.Errorf("Test fail, expected the start and the finish time, got %v", job)
+	}
+
+	// the next job is run after the failure
+	if job, _ := coordinator.Wait(ctx, coordinator.Submit().ID); job.Status != Failed {
+		t.Errorf("Test fail, expected %v, got %v", Failed, job.Status)
+	}
+}
+
+func TestCoordinatorWaitCancelled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	coordinator := NewCoordinator(func(progress ProgressFunc) error {
+		<-release
+
+		return nil
+	})
+
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	go coordinator.Run(runCtx)
+
+	id := coordinator.Submit().ID
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := coordinator.Wait(ctx, id); err != context.Canceled {
+		t.Errorf("Test fail, expected %v, got %v", context.Canceled, err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := coordinator.Wait(ctx, id); err != context.DeadlineExceeded {
+		t.Errorf("Test fail, expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	if _, err := coordinator.Wait(context.Background(), "unknown"); err != ErrJobNotFound {
+		t.Errorf("Test fail, expected %v, got %v", ErrJobNotFound, err)
+	}
+
+	if _, err := coordinator.Job("unknown"); err != ErrJobNotFound {
+		t.Errorf("Test fail, expected %v, got %v", ErrJobNotFound, err)
+	}
+}
+
+func TestCoordinatorConcurrentQueries(t *testing.T) {
+	coordinator := NewCoordinator(func(progress ProgressFunc) error {
+		for i := 1; i <= 100; i++ {
+			progress(i, 100)
+		}
+
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go coordinator.Run(ctx)
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 50; j++ {
+				job := coordinator.Submit()
+
+				if _, err := coordinator.Job(job.ID); err != nil && err != ErrJobNotFound {
+					t.Errorf("Unexpected error %v", err)
+				}
+
+				for _, job := range coordinator.Jobs() {
+					if job.Done > job.Total {
+						t.Errorf("Test fail, progress %v of %v", job.Done, job.Total)
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	job, err := coordinator.Wait(ctx, coordinator.Submit().ID)
+
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if job.Status != Succeeded || job.Done != 100 {
+		t.Errorf("Test fail, expected succeeded job, got %v %v", job.Status, job.Done)
+	}
+
+	if jobs := coordinator.Jobs(); len(jobs) > maxHistory {
+		t.Errorf("Test fail, expected at most %v jobs, got %v", maxHistory, len(jobs))
+	}
+}
@@ -0,0 +1,89 @@
+// Package rpc provides the gRPC server runner shared by the services
+package rpc
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	"github.com/suggest-go/suggest/internal/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Server serves gRPC requests until the context is done
+type Server struct {
+	server *grpc.Server
+	port   string
+	config http.ListenConfig
+}
+
+// NewServer creates new instance of gRPC Server that listens the given port on the host of the config.
+// The server should be created with ServerOptions of the same config
+func NewServer(server *grpc.Server, port string, config http.ListenConfig) *Server {
+	return &Server{
+		server: server,
+		port:   port,
+		config: config,
+	}
+}
+
+// ServerOptions returns the options of the gRPC server of the listen config, e.g. the TLS credentials
+func ServerOptions(config http.ListenConfig) ([]grpc.ServerOption, error) {
+	if config.TLS == nil {
+		return nil, nil
+	}
+
+	tlsConfig, err := http.NewTLSConfig(*config.TLS)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}, nil
+}
+
+// Run starts serving gRPC requests
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", net.JoinHostPort(s.config.GetHost(), s.port))
+
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves gRPC requests of the listener until the context is done
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		s.stop()
+	}()
+
+	if err := s.server.Serve(listener); err != nil {
+		return err
+	}
+
+	log.Println("gRPC server was shutdown gracefully")
+
+	return nil
+}
+
+// stop waits for the active calls and streams until the shutdown timeout and closes the rest connections
+func (s *Server) stop() {
+	stopped := make(chan struct{})
+
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.config.GetShutdownTimeout()):
+		log.Println("Shutdown timeout is exceeded, the active gRPC connections are closed")
+		s.server.Stop()
+	}
+}
@@ -0,0 +1,211 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/suggest-go/suggest/internal/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServerRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpc")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile, pool := writeCertificate(t, dir)
+
+	cases := []struct {
+		name   string
+		config http.ListenConfig
+		creds  credentials.TransportCredentials
+	}{
+		{"plain", http.ListenConfig{}, insecure.NewCredentials()},
+		{
+			"tls",
+			http.ListenConfig{TLS: &http.TLSConfig{CertFile: certFile, KeyFile: keyFile}},
+			credentials.NewTLS(&tls.Config{RootCAs: pool, ServerName: "localhost"}),
+		},
+	}
+
+	for _, c := range cases {
+		options, err := ServerOptions(c.config)
+
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+
+		grpcServer := grpc.NewServer(options...)
+		grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+
+		listener := bufconn.Listen(1 << 20)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() {
+			done <- NewServer(grpcServer, "", c.config).Serve(ctx, listener)
+		}()
+
+		conn, err := grpc.NewClient(
+			"passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(c.creds),
+		)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+
+		if err != nil {
+			t.Errorf("Unexpected error %v of %s", err, c.name)
+		} else if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			t.Errorf("Test fail, expected %v, got %v", grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+		}
+
+		conn.Close()
+		cancel()
+
+		if err := <-done; err != nil {
+			t.Errorf("Test fail, expected graceful stop of %s, got %v", c.name, err)
+		}
+	}
+}
+
+func TestServerOptions(t *testing.T) {
+	cases := []*http.TLSConfig{
+		{CertFile: "cert.pem"},
+		{CertFile: "missing.pem", KeyFile: "missing.key"},
+	}
+
+	for _, c := range cases {
+		if _, err := ServerOptions(http.ListenConfig{TLS: c}); err == nil {
+			t.Errorf("Test fail, expected error for %v", c)
+		}
+	}
+}
+
+// writeCertificate writes a self-signed certificate of localhost to the dir
+func writeCertificate(t *testing.T, dir string) (string, string, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	if err := ioutil.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(certPEM)
+
+	return certFile, keyFile, pool
+}
+
+func TestServerShutdownTimeout(t *testing.T) {
+	config := http.ListenConfig{ShutdownTimeout: http.Duration(50 * time.Millisecond)}
+	grpcServer := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+
+	listener := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- NewServer(grpcServer, "", config).Serve(ctx, listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	// an open stream blocks the graceful stop
+	stream, err := grpc_health_v1.NewHealthClient(conn).Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Test fail, expected stop after the shutdown timeout")
+	}
+}
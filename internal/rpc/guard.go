@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/suggest-go/suggest/internal/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ScopeFunc returns the scope required by the gRPC method
+type ScopeFunc func(fullMethod string) http.Scope
+
+// GuardOptions returns the options of the gRPC server that authenticate the clients by the same
+// API keys and tokens as the http server, check the scopes of the methods and limit the rate of
+// the requests. A streaming call takes a token of the rate limit for each received message
+func GuardOptions(guard *http.Guard, scope ScopeFunc) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryGuard(guard, scope)),
+		grpc.ChainStreamInterceptor(StreamGuard(guard, scope)),
+	}
+}
+
+// UnaryGuard returns the interceptor that authenticates and limits the unary calls
+func UnaryGuard(guard *http.Guard, scope ScopeFunc) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		clientID, err := guard.Authenticate(credential(ctx), clientAddr(ctx), scope(info.FullMethod))
+
+		if err == nil {
+			err = guard.Allow(clientID)
+		}
+
+		if err != nil {
+			return nil, guardStatus(ctx, err)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamGuard returns the interceptor that authenticates the streaming calls and limits
+// the received messages
+func StreamGuard(guard *http.Guard, scope ScopeFunc) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := stream.Context()
+		clientID, err := guard.Authenticate(credential(ctx), clientAddr(ctx), scope(info.FullMethod))
+
+		if err != nil {
+			return guardStatus(ctx, err)
+		}
+
+		return handler(srv, &guardedStream{
+			ServerStream: stream,
+			guard:        guard,
+			clientID:     clientID,
+		})
+	}
+}
+
+// guardedStream takes a token of the rate limit of the client for each received message
+type guardedStream struct {
+	grpc.ServerStream
+	guard    *http.Guard
+	clientID string
+}
+
+// RecvMsg receives a message if the client didn't exceed its rate limit
+func (s *guardedStream) RecvMsg(m interface{}) error {
+	if err := s.guard.Allow(s.clientID); err != nil {
+		return guardStatus(s.Context(), err)
+	}
+
+	return s.ServerStream.RecvMsg(m)
+}
+
+// guardStatus converts the error of the guard to the gRPC status
+func guardStatus(ctx context.Context, err error) error {
+	switch e := err.(type) {
+	case *http.ForbiddenError:
+		return status.Error(codes.PermissionDenied, e.Error())
+	case *http.RateLimitError:
+		grpc.SetHeader(ctx, metadata.Pairs("retry-after", e.RetryAfterSeconds()))
+
+		return status.Error(codes.ResourceExhausted, e.Error())
+	default:
+		return status.Error(codes.Unauthenticated, e.Error())
+	}
+}
+
+// credential returns the API key or the bearer token of the call metadata
+func credential(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return ""
+	}
+
+	return http.Credential(first(md.Get("x-api-key")), first(md.Get("authorization")))
+}
+
+// clientAddr returns the address of the client without the port
+func clientAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// first returns the first value of the metadata key
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,121 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/suggest-go/suggest/internal/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGuard(t *testing.T) {
+	guard, err := http.NewGuard(http.ServerConfig{
+		Auth: http.AuthConfig{
+			APIKeys: []http.APIKeyConfig{
+				{ID: "query", Key: "query-key", Scopes: []http.Scope{http.ScopeQuery}},
+				{ID: "admin", Key: "admin-key", Scopes: []http.Scope{http.ScopeAdmin}},
+				{
+					ID:        "limited",
+					Key:       "limited-key",
+					Scopes:    []http.Scope{http.ScopeAdmin},
+					RateLimit: &http.RateLimitConfig{Rate: 0.001, Burst: 1},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grpcServer := grpc.NewServer(GuardOptions(guard, func(fullMethod string) http.Scope {
+		if fullMethod == "/grpc.health.v1.Health/Check" {
+			return http.ScopeAdmin
+		}
+
+		return http.ScopeQuery
+	})...)
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
+
+	listener := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	go func() {
+		done <- NewServer(grpcServer, "", http.ListenConfig{}).Serve(ctx, listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	cases := []struct {
+		name     string
+		md       metadata.MD
+		expected codes.Code
+	}{
+		{"missing credential", metadata.MD{}, codes.Unauthenticated},
+		{"wrong key", metadata.Pairs("x-api-key", "wrong"), codes.Unauthenticated},
+		{"missing scope", metadata.Pairs("x-api-key", "query-key"), codes.PermissionDenied},
+		{"api key", metadata.Pairs("x-api-key", "admin-key"), codes.OK},
+		{"bearer", metadata.Pairs("authorization", "Bearer admin-key"), codes.OK},
+		{"first request", metadata.Pairs("x-api-key", "limited-key"), codes.OK},
+		{"rate limited", metadata.Pairs("x-api-key", "limited-key"), codes.ResourceExhausted},
+	}
+
+	for _, c := range cases {
+		callCtx := metadata.NewOutgoingContext(ctx, c.md)
+		_, err := client.Check(callCtx, &grpc_health_v1.HealthCheckRequest{})
+
+		if actual := status.Code(err); actual != c.expected {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+
+	streamCases := []struct {
+		name     string
+		md       metadata.MD
+		expected codes.Code
+	}{
+		{"missing credential", metadata.MD{}, codes.Unauthenticated},
+		{"query key", metadata.Pairs("x-api-key", "query-key"), codes.OK},
+	}
+
+	for _, c := range streamCases {
+		callCtx := metadata.NewOutgoingContext(ctx, c.md)
+		stream, err := client.Watch(callCtx, &grpc_health_v1.HealthCheckRequest{})
+
+		if err == nil {
+			_, err = stream.Recv()
+		}
+
+		if actual := status.Code(err); actual != c.expected {
+			t.Errorf("Test fail of stream %s, expected %v, got %v", c.name, c.expected, actual)
+		}
+	}
+}
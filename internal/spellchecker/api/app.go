@@ -2,17 +2,24 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/suggest-go/suggest/api/suggestpb"
+	"github.com/suggest-go/suggest/internal/health"
 	"github.com/suggest-go/suggest/internal/http"
+	"github.com/suggest-go/suggest/internal/monitoring"
+	"github.com/suggest-go/suggest/internal/rpc"
 	"github.com/suggest-go/suggest/internal/spellchecker/dep"
-	"github.com/suggest-go/suggest/pkg/lm"
-	"github.com/suggest-go/suggest/pkg/suggest"
+	"github.com/suggest-go/suggest/pkg/spellchecker"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 // App is our application
@@ -22,10 +29,13 @@ type App struct {
 
 // AppConfig is an application config
 type AppConfig struct {
-	Port       string
-	ConfigPath string
-	PidPath    string
-	IndexDescription suggest.IndexDescription
+	Port           string
+	ConfigPath     string
+	PidPath        string
+	ErrorModelPath string
+	ErrorWeight    float64
+	GRPCPort       string
+	DisableHTTP    bool
 }
 
 // NewApp creates new instance of App for the given config
@@ -36,49 +46,195 @@ func NewApp(config AppConfig) App {
 }
 
 // Run starts the application
-// performs http requests handling
+// performs http and gRPC requests handling
 func (a App) Run() error {
-	config, err := lm.ReadConfig(a.config.ConfigPath)
+	if a.config.DisableHTTP && a.config.GRPCPort == "" {
+		return errors.New("both http and gRPC servers are disabled")
+	}
+
+	serverConfig, err := http.ReadServerConfig(a.config.ConfigPath)
 
 	if err != nil {
-		return fmt.Errorf("failed to read config file: %v", err)
+		return err
 	}
 
-	spellchecker, err := dep.BuildSpellChecker(config, a.config.IndexDescription)
+	guard, err := http.NewGuard(serverConfig)
 
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid server config: %v", err)
+	}
+
+	service := spellchecker.NewService()
+	m := monitoring.New("spellchecker", "lang")
+	state := health.NewState(func() []health.Target {
+		return languageTargets(service)
+	})
+	reloadJob := func() error {
+		return state.Track(func() error {
+			start := time.Now()
+			err := a.configureService(service, state)
+			m.ObserveReindex(start, err)
+
+			return err
+		})
 	}
 
 	ctx, cancelFn := context.WithCancel(context.Background())
 
+	g, ctx := errgroup.WithContext(ctx)
+
+	// the signals are listened after the group has replaced ctx, the goroutine must not race with that write
 	go func() {
-		a.listenToSystemSignals(cancelFn)
+		a.listenToSystemSignals(
+			cancelFn,
+			func() {
+				if err := reloadJob(); err != nil {
+					log.Printf("Fail to reload spellcheckers %s", err)
+				} else {
+					log.Printf("Reload done!")
+				}
+			},
+		)
 	}()
 
-	r := mux.NewRouter()
-	r.StrictSlash(true)
+	// the servers are started before the initial load, /readyz tells when the languages are served
+	g.Go(func() error {
+		if err := reloadJob(); err != nil {
+			return fmt.Errorf("failed to configure service: %v", err)
+		}
 
-	r.HandleFunc("/predict/{query}/", (&predictHandler{spellchecker}).handle).Methods("GET")
+		return nil
+	})
 
-	corsHeaders := handlers.AllowedOrigins([]string{"*"})
-	corsMethods := handlers.AllowedMethods([]string{"GET"})
+	if !a.config.DisableHTTP {
+		g.Go(func() error {
+			return a.runHTTP(ctx, service, m, state, guard, serverConfig.Listen)
+		})
+	}
+
+	if a.config.GRPCPort != "" {
+		g.Go(func() error {
+			return a.runGRPC(ctx, service, m, guard, serverConfig.Listen)
+		})
+	}
+
+	return g.Wait()
+}
+
+// runHTTP serves http requests until the context is done
+func (a App) runHTTP(
+	ctx context.Context,
+	service *spellchecker.Service,
+	m *monitoring.Metrics,
+	state *health.State,
+	guard *http.Guard,
+	listen http.ListenConfig,
+) error {
+	r := mux.NewRouter()
+	r.StrictSlash(true)
+	r.UseEncodedPath()
+	r.Use(http.LimitBody(listen.GetMaxBodyBytes()), http.JSONParams)
+	r.NotFoundHandler = http.NotFoundHandler()
+	r.MethodNotAllowedHandler = http.MethodNotAllowedHandler()
+
+	h := handler{service, m}
+
+	query := r.NewRoute().Subrouter()
+	query.Use(guard.Require(http.ScopeQuery))
+	query.HandleFunc("/predict/{query}/", (&predictHandler{h}).handle).Methods("GET")
+	query.HandleFunc("/predict/", (&predictHandler{h}).handle).Methods("GET", "POST")
+	query.HandleFunc("/next/{query}/", (&nextHandler{h}).handle).Methods("GET")
+	query.HandleFunc("/next/", (&nextHandler{h}).handle).Methods("GET", "POST")
+	query.HandleFunc("/correct/{query}/", (&correctHandler{h}).handle).Methods("GET")
+	query.HandleFunc("/correct/", (&correctHandler{h}).handle).Methods("GET", "POST")
+	query.HandleFunc("/lang/list/", (&languageHandler{service}).handle).Methods("GET")
+
+	r.Handle("/metrics", m.Handler()).Methods("GET")
+	state.Register(r)
 
 	handler := handlers.LoggingHandler(os.Stdout, r)
-	handler = handlers.CORS(corsHeaders, corsMethods)(handler)
-	httpServer := http.NewServer(handler, "0.0.0.0:"+a.config.Port)
+	handler = guard.Handler(handler)
+	httpServer := http.NewServer(handler, a.config.Port, listen)
 
 	return httpServer.Run(ctx)
 }
 
+// languageTargets returns the languages served by the spellchecker service as the health targets
+func languageTargets(service *spellchecker.Service) []health.Target {
+	languages := service.GetLanguages()
+	targets := make([]health.Target, 0, len(languages))
+
+	for _, lang := range languages {
+		targets = append(targets, health.Target{Name: lang})
+	}
+
+	return targets
+}
+
+// runGRPC serves gRPC requests until the context is done
+func (a App) runGRPC(
+	ctx context.Context,
+	service *spellchecker.Service,
+	m *monitoring.Metrics,
+	guard *http.Guard,
+	listen http.ListenConfig,
+) error {
+	options, err := rpc.ServerOptions(listen)
+
+	if err != nil {
+		return err
+	}
+
+	options = append(options, rpc.GuardOptions(guard, func(string) http.Scope {
+		return http.ScopeQuery
+	})...)
+	server := grpc.NewServer(options...)
+	suggestpb.RegisterSpellCheckerServer(server, &grpcServer{
+		service: service,
+		metrics: m,
+	})
+
+	return rpc.NewServer(server, a.config.GRPCPort, listen).Run(ctx)
+}
+
+// configureService reads the languages config and sets up the spellchecker service
+func (a App) configureService(service *spellchecker.Service, state *health.State) error {
+	configs, err := dep.ReadLanguageConfigs(a.config.ConfigPath)
+
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(configs))
+
+	for _, c := range configs {
+		names = append(names, c.Lang)
+	}
+
+	if err := dep.ConfigureService(service, configs, a.config.ErrorModelPath, a.config.ErrorWeight); err != nil {
+		return err
+	}
+
+	// the new languages are awaited only once they are served, a failed reload keeps the old set
+	state.SetConfigured(names)
+
+	return nil
+}
+
 // listenToSystemSignals handles OS signals
-func (a App) listenToSystemSignals(cancelFn context.CancelFunc) {
+func (a App) listenToSystemSignals(cancelFn context.CancelFunc, reloadFn func()) {
 	signalChan := make(chan os.Signal, 1)
+	sighupChan := make(chan os.Signal, 1)
 
+	signal.Notify(sighupChan, syscall.SIGHUP)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
 
 	for {
 		select {
+		case <-sighupChan:
+			log.Println("Reload job..")
+			reloadFn()
+
 		case <-signalChan:
 			log.Println("Interrupt signal..")
 			cancelFn()
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	httputil "github.com/suggest-go/suggest/internal/http"
+)
+
+// correctHandler is responsible for the whole sentence correction using the spellchecker
+type correctHandler struct {
+	handler
+}
+
+// handle returns the most probable corrections of the provided sentence
+func (h *correctHandler) handle(w http.ResponseWriter, r *http.Request) {
+	query, lang, checker, ok := h.resolve(w, r, correctMethod)
+
+	if !ok {
+		return
+	}
+
+	topK, err := httputil.FormTopKValue(r, "topK", 1)
+
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	similarity, err := httputil.FormSimilarityValue(r, "similarity", 0.5)
+
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	start := time.Now()
+	corrections, err := checker.Correct(query, topK, similarity)
+	observeRequest(h.metrics, correctMethod, lang, start, len(corrections), err)
+
+	if err != nil {
+		writeSpellCheckerError(w, err)
+		return
+	}
+
+	httputil.WriteJSON(w, corrections)
+}
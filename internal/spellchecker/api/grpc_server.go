@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/suggest-go/suggest/api/suggestpb"
+	"github.com/suggest-go/suggest/internal/monitoring"
+	"github.com/suggest-go/suggest/pkg/spellchecker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTopK       = 5
+	defaultSimilarity = 0.5
+)
+
+// grpcServer implements suggestpb.SpellCheckerServer over the spellchecker service
+type grpcServer struct {
+	suggestpb.UnimplementedSpellCheckerServer
+	service *spellchecker.Service
+	metrics *monitoring.Metrics
+}
+
+// Predict performs prediction for the provided search query
+func (s *grpcServer) Predict(ctx context.Context, req *suggestpb.PredictRequest) (*suggestpb.PredictResponse, error) {
+	lang, checker, err := s.service.ResolveSpellChecker(req.GetLang(), req.GetQuery())
+
+	if err != nil {
+		observeRequest(s.metrics, predictMethod, lang, time.Now(), 0, err)
+	}
+
+	if err == spellchecker.ErrLanguageNotFound {
+		return nil, status.Errorf(codes.NotFound, "language %s is not found", req.GetLang())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	topK, similarity := int(req.GetTopK()), req.GetSimilarity()
+
+	if topK == 0 {
+		topK = defaultTopK
+	}
+
+	if similarity == 0 {
+		similarity = defaultSimilarity
+	}
+
+	if topK < 0 {
+		return nil, status.Error(codes.InvalidArgument, "topK should be positive integer")
+	}
+
+	if similarity < 0 || similarity > 1 {
+		return nil, status.Error(codes.InvalidArgument, "similarity should be in (0.0, 1.0]")
+	}
+
+	start := time.Now()
+	result, err := checker.Predict(req.GetQuery(), topK, similarity)
+	observeRequest(s.metrics, predictMethod, lang, start, len(result.Candidates), err)
+
+	if isInvalidArgument(err) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	candidates := make([]*suggestpb.Prediction, 0, len(result.Candidates))
+
+	for _, c := range result.Candidates {
+		candidates = append(candidates, &suggestpb.Prediction{
+			Value:      c.Value,
+			Original:   c.Original,
+			Source:     string(c.Source),
+			LmScore:    c.LMScore,
+			Similarity: c.Similarity,
+			Span: &suggestpb.Span{
+				Start: int32(c.Span.Start),
+				End:   int32(c.Span.End),
+			},
+		})
+	}
+
+	return &suggestpb.PredictResponse{
+		Original:     result.Original,
+		KeepOriginal: result.KeepOriginal,
+		Confidence:   result.Confidence,
+		Candidates:   candidates,
+	}, nil
+}
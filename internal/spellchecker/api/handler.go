@@ -0,0 +1,85 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	httputil "github.com/suggest-go/suggest/internal/http"
+	"github.com/suggest-go/suggest/internal/monitoring"
+	"github.com/suggest-go/suggest/pkg/spellchecker"
+)
+
+const (
+	predictMethod = "predict"
+	nextMethod    = "next"
+	correctMethod = "correct"
+)
+
+// handler is the base of the spellchecker handlers
+type handler struct {
+	service *spellchecker.Service
+	metrics *monitoring.Metrics
+}
+
+// resolve reads the query of the request and returns the language and the spellchecker of the
+// requested or the detected language. The error response is written if the request can't be handled
+func (h *handler) resolve(
+	w http.ResponseWriter,
+	r *http.Request,
+	method string,
+) (string, string, *spellchecker.SpellChecker, bool) {
+	query, err := httputil.QueryValue(r)
+
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return "", "", nil, false
+	}
+
+	lang, checker, err := h.service.ResolveSpellChecker(r.FormValue("lang"), query)
+
+	if err != nil {
+		observeRequest(h.metrics, method, lang, time.Now(), 0, err)
+	}
+
+	if err == spellchecker.ErrLanguageNotFound {
+		httputil.WriteError(w, http.StatusNotFound, fmt.Sprintf("language %s is not found", r.FormValue("lang")))
+		return "", "", nil, false
+	}
+
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, err.Error())
+		return "", "", nil, false
+	}
+
+	return query, lang, checker, true
+}
+
+// writeSpellCheckerError writes the error returned by the spellchecker
+func writeSpellCheckerError(w http.ResponseWriter, err error) {
+	if isInvalidArgument(err) {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httputil.WriteError(w, http.StatusInternalServerError, err.Error())
+}
+
+// isInvalidArgument tells whether the error returned by the spellchecker is caused by the query
+func isInvalidArgument(err error) bool {
+	return err == spellchecker.ErrInvalidTopK || err == spellchecker.ErrTopKLimit ||
+		err == spellchecker.ErrQueryLengthLimit
+}
+
+// observeRequest records the request of the method to the spellchecker of the language
+func observeRequest(m *monitoring.Metrics, method, lang string, start time.Time, candidates int, err error) {
+	status := monitoring.StatusOK
+
+	if err == spellchecker.ErrLanguageNotFound {
+		lang, status = monitoring.UnknownLabel, monitoring.StatusNotFound
+	} else if err != nil {
+		status = monitoring.StatusError
+	}
+
+	m.ObserveRequest(method, lang, status, time.Since(start), candidates)
+}
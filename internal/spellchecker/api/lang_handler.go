@@ -0,0 +1,18 @@
+package api
+
+import (
+	"net/http"
+
+	httputil "github.com/suggest-go/suggest/internal/http"
+	"github.com/suggest-go/suggest/pkg/spellchecker"
+)
+
+// languageHandler is responsible for listing the hosted languages
+type languageHandler struct {
+	service *spellchecker.Service
+}
+
+// handle returns all languages managed by the spellchecker service
+func (h *languageHandler) handle(w http.ResponseWriter, r *http.Request) {
+	httputil.WriteJSON(w, h.service.GetLanguages())
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	httputil "github.com/suggest-go/suggest/internal/http"
+)
+
+// nextHandler is responsible for the next word prediction using the spellchecker
+type nextHandler struct {
+	handler
+}
+
+// handle returns the most probable words that follow after the provided search query
+func (h *nextHandler) handle(w http.ResponseWriter, r *http.Request) {
+	query, lang, checker, ok := h.resolve(w, r, nextMethod)
+
+	if !ok {
+		return
+	}
+
+	topK, err := httputil.FormTopKValue(r, "topK", 5)
+
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	start := time.Now()
+	resultItems, err := checker.Next(query, topK)
+	observeRequest(h.metrics, nextMethod, lang, start, len(resultItems), err)
+
+	if err != nil {
+		writeSpellCheckerError(w, err)
+		return
+	}
+
+	httputil.WriteJSON(w, resultItems)
+}
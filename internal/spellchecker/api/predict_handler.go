@@ -1,59 +1,47 @@
 package api
 
 import (
-	"encoding/json"
-	_ "github.com/suggest-go/suggest/internal/http"
-	httputil "github.com/suggest-go/suggest/internal/http"
-	"github.com/suggest-go/suggest/pkg/spellchecker"
 	"net/http"
+	"time"
 
-	"github.com/gorilla/mux"
+	httputil "github.com/suggest-go/suggest/internal/http"
 )
 
 // predictHandler is responsible for query prediction using the spellchecker
 type predictHandler struct {
-	spellchecker *spellchecker.SpellChecker
+	handler
 }
 
 // handle performs prediction for the provided search query
 func (h *predictHandler) handle(w http.ResponseWriter, r *http.Request) {
-	var (
-		vars  = mux.Vars(r)
-		query = vars["query"]
-	)
-
-	topK, err := httputil.FormTopKValue(r, "topK", 5)
+	query, lang, checker, ok := h.resolve(w, r, predictMethod)
 
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if !ok {
 		return
 	}
 
-	similarity, err := httputil.FormSimilarityValue(r, "similarity", 0.5)
+	topK, err := httputil.FormTopKValue(r, "topK", 5)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	resultItems, err := h.spellchecker.Predict(query, topK, similarity)
+	similarity, err := httputil.FormSimilarityValue(r, "similarity", 0.5)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	data, err := json.Marshal(resultItems)
+	start := time.Now()
+	result, err := checker.Predict(query, topK, similarity)
+	observeRequest(h.metrics, predictMethod, lang, start, len(result.Candidates), err)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeSpellCheckerError(w, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-
-	if _, err := w.Write(data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	httputil.WriteJSON(w, result)
 }
@@ -0,0 +1,240 @@
+package dep
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"github.com/suggest-go/suggest/pkg/alphabet"
+	"github.com/suggest-go/suggest/pkg/lm"
+	"github.com/suggest-go/suggest/pkg/spellchecker"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+// DefaultIndexDescription is the index description of a language that does not declare its own one
+var DefaultIndexDescription = suggest.IndexDescription{
+	Driver:    suggest.RAMDriver,
+	Name:      "words",
+	NGramSize: 3,
+	Wrap:      [2]string{"^", "$"},
+	Pad:       "$",
+	Alphabet:  []string{"english", "russian", "numbers", "$^'"},
+}
+
+// LanguageConfig describes a spellchecker of one language hosted by the service
+type LanguageConfig struct {
+	// Lang is the name of the language used for the routing, e.g. "en"
+	Lang string `json:"lang"`
+	// LMConfigPath is a path to the language model config
+	LMConfigPath string `json:"lm"`
+	// Index is the description of the fuzzy search index over the lm vocabulary
+	Index *suggest.IndexDescription `json:"index"`
+	// Alphabet is used to detect the language of a query, the lm alphabet is used by default
+	Alphabet []string `json:"alphabet"`
+	// ErrorModelPath is a path to the file with (misspelling, correction) pairs
+	ErrorModelPath string `json:"errorModel"`
+	// ErrorWeight is the weight of the error model score
+	ErrorWeight float64 `json:"errorWeight"`
+	// ConfidenceThreshold is the confidence above which the original token is kept
+	ConfidenceThreshold float64 `json:"confidenceThreshold"`
+	// MaxTopK is the max allowed topK of a query, 100 by default
+	MaxTopK int `json:"maxTopK"`
+	// MaxQueryLength is the max allowed number of characters of a query, 1000 by default
+	MaxQueryLength int `json:"maxQueryLength"`
+	// MaxSplitLength is the max number of characters of a token that is split into words, 32 by default
+	MaxSplitLength int `json:"maxSplitLength"`
+	basePath       string
+}
+
+// GetLMConfigPath returns a path to the language model config
+func (c *LanguageConfig) GetLMConfigPath() string {
+	return c.resolvePath(c.LMConfigPath)
+}
+
+// GetErrorModelPath returns a path to the error model pairs, if it is declared
+func (c *LanguageConfig) GetErrorModelPath() string {
+	if c.ErrorModelPath == "" {
+		return ""
+	}
+
+	return c.resolvePath(c.ErrorModelPath)
+}
+
+// GetIndexDescription returns the index description of the language
+func (c *LanguageConfig) GetIndexDescription() suggest.IndexDescription {
+	if c.Index == nil {
+		return DefaultIndexDescription
+	}
+
+	return *c.Index
+}
+
+// GetLimits returns the limits of the queries, the omitted values are set to the defaults
+func (c *LanguageConfig) GetLimits() spellchecker.Limits {
+	limits := spellchecker.DefaultLimits()
+
+	if c.MaxTopK > 0 {
+		limits.MaxTopK = c.MaxTopK
+	}
+
+	if c.MaxQueryLength > 0 {
+		limits.MaxQueryLength = c.MaxQueryLength
+	}
+
+	if c.MaxSplitLength > 0 {
+		limits.MaxSplitLength = c.MaxSplitLength
+	}
+
+	return limits
+}
+
+// resolvePath returns the path relative to the config file, if it is not absolute
+func (c *LanguageConfig) resolvePath(p string) string {
+	if !path.IsAbs(p) {
+		return fmt.Sprintf("%s/%s", c.basePath, p)
+	}
+
+	return p
+}
+
+// ReadLanguageConfigs reads the list of spellchecker languages from the given file.
+// The list can also be given in the "languages" field of an object along with the server settings.
+// For the backward compatibility the file can also be a single lm config, in this case
+// it is treated as the only language with the default index description
+func ReadLanguageConfigs(configPath string) ([]LanguageConfig, error) {
+	data, err := ioutil.ReadFile(configPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	basePath := path.Dir(configPath)
+	var configs []LanguageConfig
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		if err := json.Unmarshal(data, &configs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config file: %v", err)
+		}
+	} else {
+		var config struct {
+			Languages []LanguageConfig `json:"languages"`
+		}
+
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config file: %v", err)
+		}
+
+		if config.Languages == nil {
+			return []LanguageConfig{{
+				Lang:         "default",
+				LMConfigPath: path.Base(configPath),
+				basePath:     basePath,
+			}}, nil
+		}
+
+		configs = config.Languages
+	}
+
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("there are no languages in %s", configPath)
+	}
+
+	seen := map[string]bool{}
+
+	for i, c := range configs {
+		if c.Lang == "" || c.LMConfigPath == "" {
+			return nil, fmt.Errorf("lang and lm should be declared for each language in %s", configPath)
+		}
+
+		if seen[c.Lang] {
+			return nil, fmt.Errorf("language %s is declared twice in %s", c.Lang, configPath)
+		}
+
+		if _, err := alphabet.ParseAlphabet(c.Alphabet); err != nil {
+			return nil, fmt.Errorf("invalid alphabet of %s in %s: %v", c.Lang, configPath, err)
+		}
+
+		if c.MaxTopK < 0 || c.MaxQueryLength < 0 || c.MaxSplitLength < 0 {
+			return nil, fmt.Errorf("limits of %s in %s should not be negative", c.Lang, configPath)
+		}
+
+		seen[c.Lang] = true
+		c.basePath = basePath
+		configs[i] = c
+	}
+
+	return configs, nil
+}
+
+// ConfigureService builds spellcheckers of the given languages and replaces the languages of the service
+// with them, the languages that are not declared anymore are dropped. The service is updated only if all
+// the spellcheckers are built successfully. The error model flags are applied to the languages that do
+// not declare their own error model
+func ConfigureService(
+	service *spellchecker.Service,
+	configs []LanguageConfig,
+	errorModelPath string,
+	errorWeight float64,
+) error {
+	type language struct {
+		lang         string
+		alphabet     alphabet.Alphabet
+		spellchecker *spellchecker.SpellChecker
+	}
+
+	languages := make([]language, 0, len(configs))
+
+	for _, c := range configs {
+		config, err := lm.ReadConfig(c.GetLMConfigPath())
+
+		if err != nil {
+			return fmt.Errorf("failed to read lm config of %s: %v", c.Lang, err)
+		}
+
+		modelPath, weight := c.GetErrorModelPath(), c.ErrorWeight
+
+		if modelPath == "" {
+			modelPath = errorModelPath
+		}
+
+		if weight == 0 {
+			weight = errorWeight
+		}
+
+		checker, err := BuildSpellChecker(config, c.GetIndexDescription(), modelPath, weight)
+
+		if err != nil {
+			return fmt.Errorf("failed to build spellchecker of %s: %v", c.Lang, err)
+		}
+
+		if c.ConfidenceThreshold > 0 {
+			checker.SetConfidenceThreshold(c.ConfidenceThreshold)
+		}
+
+		checker.SetLimits(c.GetLimits())
+
+		detector := config.GetWordsAlphabet()
+
+		if len(c.Alphabet) > 0 {
+			detector = alphabet.CreateAlphabet(c.Alphabet)
+		}
+
+		languages = append(languages, language{
+			lang:         c.Lang,
+			alphabet:     detector,
+			spellchecker: checker,
+		})
+	}
+
+	next := spellchecker.NewService()
+
+	for _, l := range languages {
+		next.AddSpellChecker(l.lang, l.alphabet, l.spellchecker)
+	}
+
+	service.Replace(next)
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package dep
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/lm"
+	"github.com/suggest-go/suggest/pkg/store"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+// BuildIndex builds the n-gram index of the lm vocabulary and persists it into the lm output directory.
+// The manifest of the dictionary is saved next to the index, it tells whether the index is up to date
+func BuildIndex(config *lm.Config, indexDescription suggest.IndexDescription) error {
+	if err := checkSurfaceWords(config); err != nil {
+		return err
+	}
+
+	dict, err := dictionary.OpenCDBDictionary(config.GetDictionaryPath())
+
+	if err != nil {
+		return fmt.Errorf("failed to open a cdb dictionary: %v", err)
+	}
+
+	description, err := vocabularyIndexDescription(config, indexDescription)
+
+	if err != nil {
+		return err
+	}
+
+	directory, err := store.NewFSDirectory(description.GetIndexPath())
+
+	if err != nil {
+		return fmt.Errorf("failed to create a fs directory: %v", err)
+	}
+
+	// the index is not trusted until it is built completely
+	manifestPath := indexManifestPath(description)
+
+	if err := os.Remove(manifestPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove the index manifest: %v", err)
+	}
+
+	tokenizer, err := description.GetIndexTokenizer()
+
+	if err != nil {
+		return fmt.Errorf("failed to create an index tokenizer: %v", err)
+	}
+
+	if err := suggest.Index(directory, dict, description.GetWriterConfig(), tokenizer); err != nil {
+		return fmt.Errorf("failed to index the lm vocabulary: %v", err)
+	}
+
+	return writeIndexManifest(config.GetDictionaryPath(), description)
+}
+
+// newIndexBuilder returns a builder of the n-gram index of the lm vocabulary. The index
+// persisted by BuildIndex is used if its manifest matches the dictionary and the index description,
+// otherwise the index is built in RAM
+func newIndexBuilder(
+	config *lm.Config,
+	dict dictionary.Dictionary,
+	indexDescription suggest.IndexDescription,
+) (suggest.Builder, error) {
+	description, err := vocabularyIndexDescription(config, indexDescription)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fresh, err := isIndexFresh(config.GetDictionaryPath(), description)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !fresh {
+		log.Printf("The index %s is missing or stale, rebuild it with the build command", description.GetIndexPath())
+
+		return suggest.NewRAMBuilder(dict, indexDescription)
+	}
+
+	return suggest.NewFSBuilder(description)
+}
+
+// indexManifest describes the dictionary and the description the persisted index is built from
+type indexManifest struct {
+	DictionarySize  int64  `json:"dictionarySize"`
+	DictionaryHash  string `json:"dictionaryHash"`
+	DescriptionHash string `json:"descriptionHash"`
+}
+
+// newIndexManifest returns the manifest of the given dictionary file and index description
+func newIndexManifest(dictPath string, description suggest.IndexDescription) (indexManifest, error) {
+	info, err := os.Stat(dictPath)
+
+	if err != nil {
+		return indexManifest{}, fmt.Errorf("failed to stat the dictionary: %v", err)
+	}
+
+	dictHash, err := hashFile(dictPath)
+
+	if err != nil {
+		return indexManifest{}, err
+	}
+
+	descriptionHash, err := hashDescription(description)
+
+	if err != nil {
+		return indexManifest{}, err
+	}
+
+	return indexManifest{
+		DictionarySize:  info.Size(),
+		DictionaryHash:  dictHash,
+		DescriptionHash: descriptionHash,
+	}, nil
+}
+
+// writeIndexManifest saves the manifest of the dictionary and the index description next to the index
+func writeIndexManifest(dictPath string, description suggest.IndexDescription) error {
+	manifest, err := newIndexManifest(dictPath, description)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(manifest)
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal the index manifest: %v", err)
+	}
+
+	if err := ioutil.WriteFile(indexManifestPath(description), data, 0644); err != nil {
+		return fmt.Errorf("failed to write the index manifest: %v", err)
+	}
+
+	return nil
+}
+
+// isIndexFresh tells whether the persisted index is built from the current dictionary with the same
+// description. The content of the dictionary is compared, so a copied or touched file doesn't matter
+func isIndexFresh(dictPath string, description suggest.IndexDescription) (bool, error) {
+	header := filepath.Join(description.GetIndexPath(), description.GetWriterConfig().HeaderFileName)
+
+	if _, err := os.Stat(header); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to stat the index header: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(indexManifestPath(description))
+
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to read the index manifest: %v", err)
+	}
+
+	manifest := indexManifest{}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		log.Printf("The index manifest is broken: %v", err)
+
+		return false, nil
+	}
+
+	descriptionHash, err := hashDescription(description)
+
+	if err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(dictPath)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to stat the dictionary: %v", err)
+	}
+
+	if manifest.DescriptionHash != descriptionHash || manifest.DictionarySize != info.Size() {
+		return false, nil
+	}
+
+	dictHash, err := hashFile(dictPath)
+
+	if err != nil {
+		return false, err
+	}
+
+	return manifest.DictionaryHash == dictHash, nil
+}
+
+// indexManifestPath returns the path of the manifest of the persisted index
+func indexManifestPath(description suggest.IndexDescription) string {
+	return filepath.Join(description.GetIndexPath(), fmt.Sprintf("%s.manifest.json", description.Name))
+}
+
+// hashFile returns the hex encoded SHA-256 of the file content
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %v", path, err)
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %v", path, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// hashDescription returns the hex encoded SHA-256 of the index description
+func hashDescription(description suggest.IndexDescription) (string, error) {
+	data, err := json.Marshal(description)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the index description: %v", err)
+	}
+
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// vocabularyIndexDescription returns the index description which output path is the lm output directory
+func vocabularyIndexDescription(
+	config *lm.Config,
+	indexDescription suggest.IndexDescription,
+) (suggest.IndexDescription, error) {
+	outputPath, err := filepath.Abs(config.GetOutputPath())
+
+	if err != nil {
+		return suggest.IndexDescription{}, fmt.Errorf("failed to resolve the lm output path: %v", err)
+	}
+
+	indexDescription.Driver = suggest.DiscDriver
+	indexDescription.OutputPath = outputPath
+
+	return indexDescription, nil
+}
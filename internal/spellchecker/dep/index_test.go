@@ -0,0 +1,139 @@
+package dep
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+func TestIsIndexFresh(t *testing.T) {
+	cases := []struct {
+		name     string
+		change   func(dictPath string, description *suggest.IndexDescription) error
+		expected bool
+	}{
+		{
+			"fresh",
+			func(string, *suggest.IndexDescription) error { return nil },
+			true,
+		},
+		{
+			"touched dictionary",
+			func(dictPath string, _ *suggest.IndexDescription) error {
+				later := time.Now().Add(time.Hour)
+
+				return os.Chtimes(dictPath, later, later)
+			},
+			true,
+		},
+		{
+			"same size dictionary",
+			func(dictPath string, _ *suggest.IndexDescription) error {
+				return writeAndKeepModTime(dictPath, "green eggs and jam")
+			},
+			false,
+		},
+		{
+			"larger dictionary",
+			func(dictPath string, _ *suggest.IndexDescription) error {
+				return ioutil.WriteFile(dictPath, []byte("green eggs and ham, sam"), 0644)
+			},
+			false,
+		},
+		{
+			"changed description",
+			func(_ string, description *suggest.IndexDescription) error {
+				description.NGramSize = 3
+
+				return nil
+			},
+			false,
+		},
+		{
+			"missing manifest",
+			func(_ string, description *suggest.IndexDescription) error {
+				return os.Remove(indexManifestPath(*description))
+			},
+			false,
+		},
+		{
+			"missing index",
+			func(_ string, description *suggest.IndexDescription) error {
+				return os.Remove(filepath.Join(description.GetIndexPath(), description.GetWriterConfig().HeaderFileName))
+			},
+			false,
+		},
+		{
+			"broken manifest",
+			func(_ string, description *suggest.IndexDescription) error {
+				return ioutil.WriteFile(indexManifestPath(*description), []byte("{"), 0644)
+			},
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		dir, err := ioutil.TempDir("", "index")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dictPath := filepath.Join(dir, "test.cdb")
+		description := suggest.IndexDescription{
+			Driver:     suggest.DiscDriver,
+			Name:       "words",
+			NGramSize:  2,
+			OutputPath: dir,
+		}
+
+		if err := ioutil.WriteFile(dictPath, []byte("green eggs and ham"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		header := filepath.Join(dir, description.GetWriterConfig().HeaderFileName)
+
+		if err := ioutil.WriteFile(header, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := writeIndexManifest(dictPath, description); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+
+		if err := c.change(dictPath, &description); err != nil {
+			t.Fatal(err)
+		}
+
+		fresh, err := isIndexFresh(dictPath, description)
+
+		if err != nil {
+			t.Errorf("Unexpected error %v of %s", err, c.name)
+		}
+
+		if fresh != c.expected {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.expected, fresh)
+		}
+
+		os.RemoveAll(dir)
+	}
+}
+
+// writeAndKeepModTime replaces the content of the file and restores its modification time
+func writeAndKeepModTime(path, content string) error {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	return os.Chtimes(path, info.ModTime(), info.ModTime())
+}
@@ -9,8 +9,18 @@ import (
 	"github.com/suggest-go/suggest/pkg/suggest"
 )
 
-// BuildSpellChecker builds spellchecker for the provided config and indexDescription
-func BuildSpellChecker(config *lm.Config, indexDescription suggest.IndexDescription) (*spellchecker.SpellChecker, error) {
+// BuildSpellChecker builds spellchecker for the provided config and indexDescription.
+// The index persisted by BuildIndex is opened if it is present. The error model is trained on the errorModelPath pairs, if the path is provided
+func BuildSpellChecker(
+	config *lm.Config,
+	indexDescription suggest.IndexDescription,
+	errorModelPath string,
+	errorWeight float64,
+) (*spellchecker.SpellChecker, error) {
+	if err := checkSurfaceWords(config); err != nil {
+		return nil, err
+	}
+
 	directory, err := store.NewFSDirectory(config.GetOutputPath())
 
 	if err != nil {
@@ -29,8 +39,8 @@ func BuildSpellChecker(config *lm.Config, indexDescription suggest.IndexDescript
 		return nil, fmt.Errorf("failed to open a cdb dictionary: %v", err)
 	}
 
-	// create runtime search index builder
-	builder, err := suggest.NewRAMBuilder(dict, indexDescription)
+	// open the persisted search index or build it in runtime
+	builder, err := newIndexBuilder(config, dict, indexDescription)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a ngram index: %v", err)
@@ -42,10 +52,45 @@ func BuildSpellChecker(config *lm.Config, indexDescription suggest.IndexDescript
 		return nil, fmt.Errorf("failed to build a ngram index: %v", err)
 	}
 
-	return spellchecker.New(
+	normalizer, err := config.GetNormalizer()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a normalizer: %v", err)
+	}
+
+	tokenizer, err := config.GetTokenizer()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a tokenizer: %v", err)
+	}
+
+	checker := spellchecker.New(
 		index,
 		languageModel,
-		lm.NewTokenizer(config.GetWordsAlphabet()),
+		tokenizer,
 		dict,
-	), nil
+	)
+	checker.SetNormalizer(normalizer)
+
+	if errorModelPath != "" {
+		errorModel, err := spellchecker.ReadErrorModel(errorModelPath)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to train an error model: %v", err)
+		}
+
+		checker.SetErrorModel(errorModel, errorWeight)
+	}
+
+	return checker, nil
+}
+
+// checkSurfaceWords checks that the language model counts the words as is. The spellchecker
+// predicts and corrects with the vocabulary of the model, so the stems would be returned to the users
+func checkSurfaceWords(config *lm.Config) error {
+	if config.Stemmer != "" {
+		return fmt.Errorf("stemmer %s of the lm %s is not supported by the spellchecker", config.Stemmer, config.Name)
+	}
+
+	return nil
 }
@@ -0,0 +1,110 @@
+package dep
+
+import (
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/lm"
+	"github.com/suggest-go/suggest/pkg/spellchecker"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+// lmConfigPath is the config of the test language model
+const lmConfigPath = "../../../pkg/lm/testdata/config-example.json"
+
+func TestBuildSpellCheckerPredictsWords(t *testing.T) {
+	config, err := lm.ReadConfig(lmConfigPath)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	checker, err := BuildSpellChecker(config, testIndexDescription(), "", 0)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{"green eggs", "eggs"},
+		{"i do not liked", "like"},
+	}
+
+	for _, c := range cases {
+		result, err := checker.Predict(c.query, 5, 0.3)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if len(result.Candidates) == 0 || result.Candidates[0].Value != c.expected {
+			t.Errorf("Test fail, for %s expected %v, got %v", c.query, c.expected, result.Candidates)
+		}
+	}
+}
+
+func TestBuildSpellCheckerRejectsStemmer(t *testing.T) {
+	config, err := lm.ReadConfig(lmConfigPath)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	config.Stemmer = "english"
+
+	if _, err := BuildSpellChecker(config, testIndexDescription(), "", 0); err == nil {
+		t.Errorf("Test fail, expected the stemmed lm to be rejected")
+	}
+
+	if err := BuildIndex(config, testIndexDescription()); err == nil {
+		t.Errorf("Test fail, expected the stemmed lm to be rejected")
+	}
+}
+
+// testIndexDescription returns the description of the vocabulary index of the test language model
+func testIndexDescription() suggest.IndexDescription {
+	return suggest.IndexDescription{
+		Name:      "words",
+		NGramSize: 2,
+		Wrap:      [2]string{"^", "$"},
+		Pad:       "$",
+		Alphabet:  []string{"english"},
+	}
+}
+
+func TestConfigureServiceDropsRemovedLanguages(t *testing.T) {
+	config := func(lang string) LanguageConfig {
+		return LanguageConfig{Lang: lang, LMConfigPath: lmConfigPath, basePath: "."}
+	}
+
+	service := spellchecker.NewService()
+
+	if err := ConfigureService(service, []LanguageConfig{config("en"), config("ru")}, "", 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := ConfigureService(service, []LanguageConfig{config("en")}, "", 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if languages := service.GetLanguages(); len(languages) != 1 || languages[0] != "en" {
+		t.Errorf("Test fail, expected [en], got %v", languages)
+	}
+
+	if _, err := service.GetSpellChecker("ru", "green eggs"); err != spellchecker.ErrLanguageNotFound {
+		t.Errorf("Test fail, expected %v, got %v", spellchecker.ErrLanguageNotFound, err)
+	}
+
+	// a failed reload keeps the served languages
+	broken := LanguageConfig{Lang: "de", LMConfigPath: "missing.json", basePath: "."}
+
+	if err := ConfigureService(service, []LanguageConfig{broken}, "", 0); err == nil {
+		t.Errorf("Test fail, expected error of the missing lm config")
+	}
+
+	if _, err := service.GetSpellChecker("en", "green eggs"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/suggest-go/suggest/internal/http"
 	"io/ioutil"
@@ -11,10 +12,18 @@ import (
 	"path/filepath"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/suggest-go/suggest/api/suggestpb"
+	"github.com/suggest-go/suggest/internal/health"
+	"github.com/suggest-go/suggest/internal/monitoring"
+	"github.com/suggest-go/suggest/internal/reindex"
+	"github.com/suggest-go/suggest/internal/rpc"
 	"github.com/suggest-go/suggest/pkg/suggest"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 )
 
 // App is our application
@@ -24,9 +33,12 @@ type App struct {
 
 // AppConfig is an application config
 type AppConfig struct {
-	Port       string
-	ConfigPath string
-	PidPath    string
+	Port         string
+	ConfigPath   string
+	PidPath      string
+	GRPCPort     string
+	DisableHTTP  bool
+	BatchWorkers int
 }
 
 // NewApp creates new instance of App for the given config
@@ -37,29 +49,58 @@ func NewApp(config AppConfig) App {
 }
 
 // Run starts the application
-// performs http requests handling
+// performs http and (or) gRPC requests handling
 func (a App) Run() error {
+	if a.config.DisableHTTP && a.config.GRPCPort == "" {
+		return errors.New("both http and gRPC servers are disabled")
+	}
+
 	if err := a.writePIDFile(); err != nil {
 		return err
 	}
 
-	suggestService := suggest.NewService()
-	reindexJob := func() error {
-		return a.configureService(suggestService)
+	serverConfig, err := http.ReadServerConfig(a.config.ConfigPath)
+
+	if err != nil {
+		return err
 	}
 
-	if err := reindexJob(); err != nil {
-		return fmt.Errorf("Fail to configure service: %s", err)
+	guard, err := http.NewGuard(serverConfig)
+
+	if err != nil {
+		return fmt.Errorf("invalid server config: %v", err)
 	}
 
+	suggestService := suggest.NewService()
+	m := a.newMetrics(suggestService)
+	state := health.NewState(func() []health.Target {
+		return dictionaryTargets(suggestService)
+	})
+	coordinator := reindex.NewCoordinator(func(progress reindex.ProgressFunc) error {
+		return state.Track(func() error {
+			start := time.Now()
+			err := a.configureService(suggestService, state, progress)
+			m.ObserveReindex(start, err)
+
+			return err
+		})
+	})
+
 	ctx, cancelFn := context.WithCancel(context.Background())
 
+	g, ctx := errgroup.WithContext(ctx)
+
+	// the signals are handled after the group is created, so the reload waits with the group context
 	go func() {
 		a.listenToSystemSignals(
 			cancelFn,
 			func() {
-				if err := reindexJob(); err != nil {
-					log.Printf("Fail to reload index %s", err)
+				job, err := coordinator.Wait(ctx, coordinator.Submit().ID)
+
+				if err != nil {
+					log.Printf("Fail to wait for reindex %s", err)
+				} else if job.Status == reindex.Failed {
+					log.Printf("Fail to reload index %s", job.Error)
 				} else {
 					log.Printf("Reindex done!")
 				}
@@ -67,24 +108,155 @@ func (a App) Run() error {
 		)
 	}()
 
+	g.Go(func() error {
+		return coordinator.Run(ctx)
+	})
+
+	// the servers are started before the initial load, /readyz tells when the dictionaries are served
+	g.Go(func() error {
+		job, err := coordinator.Wait(ctx, coordinator.Submit().ID)
+
+		if err != nil {
+			return err
+		}
+
+		if job.Status == reindex.Failed {
+			return fmt.Errorf("Fail to configure service: %s", job.Error)
+		}
+
+		return nil
+	})
+
+	if !a.config.DisableHTTP {
+		g.Go(func() error {
+			return a.runHTTP(ctx, suggestService, coordinator, m, state, guard, serverConfig.Listen)
+		})
+	}
+
+	if a.config.GRPCPort != "" {
+		g.Go(func() error {
+			return a.runGRPC(ctx, suggestService, coordinator, guard, serverConfig.Listen)
+		})
+	}
+
+	return g.Wait()
+}
+
+// runHTTP serves REST API requests until the context is done
+func (a App) runHTTP(
+	ctx context.Context,
+	suggestService *suggest.Service,
+	coordinator *reindex.Coordinator,
+	m *monitoring.Metrics,
+	state *health.State,
+	guard *http.Guard,
+	listen http.ListenConfig,
+) error {
 	r := mux.NewRouter()
 	r.StrictSlash(true)
+	r.UseEncodedPath()
+	r.Use(http.LimitBody(listen.GetMaxBodyBytes()), http.JSONParams)
+	r.NotFoundHandler = http.NotFoundHandler()
+	r.MethodNotAllowedHandler = http.MethodNotAllowedHandler()
+
+	query := r.NewRoute().Subrouter()
+	query.Use(guard.Require(http.ScopeQuery))
+	query.HandleFunc("/autocomplete/{dict}/{query}/", (&autocompleteHandler{suggestService}).handle).Methods("GET")
+	query.HandleFunc("/autocomplete/{dict}/", (&autocompleteHandler{suggestService}).handle).Methods("GET", "POST")
+	query.HandleFunc("/suggest/{dict}/{query}/", (&suggestHandler{suggestService}).handle).Methods("GET")
+	query.HandleFunc("/suggest/{dict}/", (&suggestHandler{suggestService}).handle).Methods("GET", "POST")
+	query.HandleFunc("/dict/list/", (&dictionaryHandler{suggestService}).handle).Methods("GET")
+	query.HandleFunc("/batch/suggest/", (&batchHandler{suggestService, a.config.BatchWorkers}).handleSuggest).Methods("POST")
+	query.HandleFunc("/batch/autocomplete/", (&batchHandler{suggestService, a.config.BatchWorkers}).handleAutocomplete).Methods("POST")
 
-	r.HandleFunc("/autocomplete/{dict}/{query}/", (&autocompleteHandler{suggestService}).handle).Methods("GET")
-	r.HandleFunc("/suggest/{dict}/{query}/", (&suggestHandler{suggestService}).handle).Methods("GET")
-	r.HandleFunc("/dict/list/", (&dictionaryHandler{suggestService}).handle).Methods("GET")
-	r.HandleFunc("/internal/reindex/", (&reindexHandler{reindexJob}).handle).Methods("POST")
+	admin := r.PathPrefix("/internal/").Subrouter()
+	admin.Use(guard.Require(http.ScopeAdmin))
+	admin.HandleFunc("/reindex/", (&reindexHandler{coordinator}).handle).Methods("POST")
+	admin.HandleFunc("/reindex/", (&reindexHandler{coordinator}).handleList).Methods("GET")
+	admin.HandleFunc("/reindex/{id}/", (&reindexHandler{coordinator}).handleJob).Methods("GET")
 
-	corsHeaders := handlers.AllowedOrigins([]string{"*"})
-	corsMethods := handlers.AllowedMethods([]string{"GET"})
+	r.Handle("/metrics", m.Handler()).Methods("GET")
+	state.Register(r)
 
 	handler := handlers.LoggingHandler(os.Stdout, r)
-	handler = handlers.CORS(corsHeaders, corsMethods)(handler)
-	httpServer := http.NewServer(handler, "0.0.0.0:"+a.config.Port)
+	handler = guard.Handler(handler)
+	httpServer := http.NewServer(handler, a.config.Port, listen)
 
 	return httpServer.Run(ctx)
 }
 
+// newMetrics creates the metrics of the service and subscribes them to the suggest service requests
+func (a App) newMetrics(suggestService *suggest.Service) *monitoring.Metrics {
+	m := monitoring.New("suggest", "dict")
+	m.RegisterSizes("dictionary_size", "The number of items of the dictionary.", "dict", suggestService.GetDictionarySizes)
+
+	suggestService.SetRequestObserver(func(method, dict string, elapsed time.Duration, candidates int, err error) {
+		status := monitoring.StatusOK
+
+		if err == suggest.ErrDictionaryNotFound {
+			dict, status = monitoring.UnknownLabel, monitoring.StatusNotFound
+		} else if err != nil {
+			status = monitoring.StatusError
+		}
+
+		m.ObserveRequest(method, dict, status, elapsed, candidates)
+	})
+
+	return m
+}
+
+// dictionaryTargets returns the dictionaries served by the suggest service as the health targets
+func dictionaryTargets(suggestService *suggest.Service) []health.Target {
+	infos := suggestService.GetDictionaryInfos()
+	targets := make([]health.Target, 0, len(infos))
+
+	for _, info := range infos {
+		loadedAt := info.LoadedAt
+		targets = append(targets, health.Target{
+			Name:       info.Name,
+			Generation: info.Generation,
+			LoadedAt:   &loadedAt,
+			Size:       info.Size,
+		})
+	}
+
+	return targets
+}
+
+// runGRPC serves gRPC requests until the context is done
+func (a App) runGRPC(
+	ctx context.Context,
+	suggestService *suggest.Service,
+	coordinator *reindex.Coordinator,
+	guard *http.Guard,
+	listen http.ListenConfig,
+) error {
+	options, err := rpc.ServerOptions(listen)
+
+	if err != nil {
+		return err
+	}
+
+	options = append(options, rpc.GuardOptions(guard, grpcScope)...)
+	server := grpc.NewServer(options...)
+	suggestpb.RegisterSuggestServer(server, &grpcServer{
+		suggestService: suggestService,
+		coordinator:    coordinator,
+	})
+
+	return rpc.NewServer(server, a.config.GRPCPort, listen).Run(ctx)
+}
+
+// grpcScope returns the scope of the gRPC method, the reindex methods are administrative as the http ones
+func grpcScope(fullMethod string) http.Scope {
+	switch fullMethod {
+	case suggestpb.Suggest_Reindex_FullMethodName, suggestpb.Suggest_GetReindexJob_FullMethodName:
+		return http.ScopeAdmin
+	default:
+		return http.ScopeQuery
+	}
+}
+
 // writePIDFile performs writing a PID of the application service
 func (a App) writePIDFile() error {
 	if a.config.PidPath == "" {
@@ -105,20 +277,40 @@ func (a App) writePIDFile() error {
 	return nil
 }
 
-// configureService tries to retrieve index descriptions and to setup the suggest service
-func (a App) configureService(suggestService *suggest.Service) error {
+// configureService builds the indexes of the configured dictionaries aside and replaces
+// the indexes of the suggest service with them at once
+func (a App) configureService(
+	suggestService *suggest.Service,
+	state *health.State,
+	progress reindex.ProgressFunc,
+) error {
 	description, err := suggest.ReadConfigs(a.config.ConfigPath)
 
 	if err != nil {
 		return err
 	}
 
+	names := make([]string, 0, len(description))
+
 	for _, config := range description {
-		if err := suggestService.AddIndexByDescription(config); err != nil {
+		names = append(names, config.Name)
+	}
+
+	next := suggest.NewService()
+	progress(0, len(description))
+
+	for i, config := range description {
+		if err := next.AddIndexByDescription(config); err != nil {
 			return err
 		}
+
+		progress(i+1, len(description))
 	}
 
+	suggestService.Replace(next)
+	// the new names are awaited only once they are served, a failed reindex keeps the old set
+	state.SetConfigured(names)
+
 	return nil
 }
 
@@ -1,11 +1,9 @@
 package api
 
 import (
-	"encoding/json"
-	httputil "github.com/suggest-go/suggest/internal/http"
 	"net/http"
 
-	"github.com/gorilla/mux"
+	httputil "github.com/suggest-go/suggest/internal/http"
 	"github.com/suggest-go/suggest/pkg/suggest"
 )
 
@@ -16,37 +14,33 @@ type autocompleteHandler struct {
 
 // handle performs autocomplete for the given query
 func (h *autocompleteHandler) handle(w http.ResponseWriter, r *http.Request) {
-	var (
-		vars  = mux.Vars(r)
-		dict  = vars["dict"]
-		query = vars["query"]
-	)
-
-	topK, err := httputil.FormTopKValue(r, "topK", defaultTopK)
+	dict, err := httputil.PathValue(r, "dict")
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	resultItems, err := h.suggestService.Autocomplete(dict, query, topK)
+	query, err := httputil.QueryValue(r)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	data, err := json.Marshal(resultItems)
+	topK, err := httputil.FormTopKValue(r, "topK", 0)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	resultItems, err := h.suggestService.Autocomplete(dict, query, topK)
 
-	if _, err := w.Write(data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err != nil {
+		writeServiceError(w, dict, err)
 		return
 	}
+
+	httputil.WriteJSON(w, resultItems)
 }
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	httputil "github.com/suggest-go/suggest/internal/http"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+// maxBatchSize is the max number of queries of a batch request
+const maxBatchSize = 1000
+
+// batchQuery is a query of a batch request
+type batchQuery struct {
+	Dict       string   `json:"dict"`
+	Query      string   `json:"query"`
+	Metric     string   `json:"metric"`
+	TopK       *int     `json:"topK"`
+	Similarity *float64 `json:"similarity"`
+}
+
+// batchResult is a result of a query of a batch request
+type batchResult struct {
+	Items []suggest.ResultItem `json:"items"`
+	Error string               `json:"error,omitempty"`
+}
+
+// batchHandler handles batch suggest and autocomplete requests
+type batchHandler struct {
+	suggestService *suggest.Service
+	workers        int
+}
+
+// handleSuggest performs topK approximate string search for each query of the batch
+func (h *batchHandler) handleSuggest(w http.ResponseWriter, r *http.Request) {
+	queries, err := decodeBatch(r)
+
+	if err == httputil.ErrBodyTooLarge {
+		httputil.WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	} else if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	batch := make([]suggest.BatchQuery, 0, len(queries))
+
+	for i, q := range queries {
+		searchConf, err := q.searchConfig(h.suggestService)
+
+		// the unknown dictionary is reported in the result of the query
+		if err != nil && err != suggest.ErrDictionaryNotFound {
+			httputil.WriteError(w, http.StatusBadRequest, fmt.Sprintf("query %d: %v", i, err))
+			return
+		}
+
+		batch = append(batch, suggest.BatchQuery{Dict: q.Dict, Config: searchConf})
+	}
+
+	writeBatchResults(w, h.suggestService.SuggestBatch(batch, h.workers))
+}
+
+// handleAutocomplete performs autocomplete for each query of the batch
+func (h *batchHandler) handleAutocomplete(w http.ResponseWriter, r *http.Request) {
+	queries, err := decodeBatch(r)
+
+	if err == httputil.ErrBodyTooLarge {
+		httputil.WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	} else if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	batch := make([]suggest.AutocompleteQuery, 0, len(queries))
+
+	for i, q := range queries {
+		topK, err := q.topK()
+
+		if err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, fmt.Sprintf("query %d: %v", i, err))
+			return
+		}
+
+		batch = append(batch, suggest.AutocompleteQuery{Dict: q.Dict, Query: q.Query, Limit: topK})
+	}
+
+	writeBatchResults(w, h.suggestService.AutocompleteBatch(batch, h.workers))
+}
+
+// decodeBatch reads the list of queries from the request body, the decoding stops
+// as soon as the batch exceeds maxBatchSize
+func decodeBatch(r *http.Request) ([]batchQuery, error) {
+	decoder := json.NewDecoder(r.Body)
+
+	if token, err := decoder.Token(); err == httputil.ErrBodyTooLarge {
+		return nil, err
+	} else if err != nil || token != json.Delim('[') {
+		return nil, errors.New("failed to decode batch: batch should be an array of queries")
+	}
+
+	queries := []batchQuery{}
+
+	for decoder.More() {
+		if len(queries) == maxBatchSize {
+			return nil, fmt.Errorf("batch size should be at most %d", maxBatchSize)
+		}
+
+		query := batchQuery{}
+
+		if err := decoder.Decode(&query); err == httputil.ErrBodyTooLarge {
+			return nil, err
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode batch: %v", err)
+		}
+
+		queries = append(queries, query)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("failed to decode batch: %v", err)
+	}
+
+	return queries, nil
+}
+
+// writeBatchResults writes the results of the batch in the order of the queries
+func writeBatchResults(w http.ResponseWriter, results []suggest.BatchResult) {
+	response := make([]batchResult, 0, len(results))
+
+	for _, result := range results {
+		item := batchResult{Items: result.Items}
+
+		if item.Items == nil {
+			item.Items = []suggest.ResultItem{}
+		}
+
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+		}
+
+		response = append(response, item)
+	}
+
+	httputil.WriteJSON(w, response)
+}
+
+// searchConfig builds a search config of the query, the omitted parameters are set to the
+// defaults of the dictionary
+func (q batchQuery) searchConfig(service *suggest.Service) (suggest.SearchConfig, error) {
+	topK, err := q.topK()
+
+	if err != nil {
+		return suggest.SearchConfig{}, err
+	}
+
+	similarity := 0.0
+
+	if q.Similarity != nil {
+		similarity = *q.Similarity
+	}
+
+	if similarity < 0 || similarity > 1 {
+		return suggest.SearchConfig{}, errors.New("similarity should be in [0, 1] range")
+	}
+
+	return service.NewSearchConfig(q.Dict, q.Query, suggest.QueryOptions{
+		TopK:       topK,
+		Metric:     q.Metric,
+		Similarity: similarity,
+	})
+}
+
+// topK returns the topK of the query or 0 to use the default one of the dictionary
+func (q batchQuery) topK() (int, error) {
+	if q.TopK == nil {
+		return 0, nil
+	}
+
+	if *q.TopK < 0 {
+		return 0, errors.New("topK should be positive integer")
+	}
+
+	return *q.TopK, nil
+}
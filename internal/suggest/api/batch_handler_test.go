@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httputil "github.com/suggest-go/suggest/internal/http"
+)
+
+func TestDecodeBatch(t *testing.T) {
+	query := `{"dict": "cars", "query": "nissan"}`
+	full := "[" + strings.TrimSuffix(strings.Repeat(query+",", maxBatchSize), ",") + "]"
+
+	cases := []struct {
+		name    string
+		body    string
+		size    int
+		isError bool
+	}{
+		{"empty batch", `[]`, 0, false},
+		{"single query", "[" + query + "]", 1, false},
+		{"max batch", full, maxBatchSize, false},
+		{"exceeded batch", "[" + query + "," + full[1:], 0, true},
+		{"object", query, 0, true},
+		{"unterminated batch", "[" + query, 0, true},
+	}
+
+	for _, c := range cases {
+		r := httptest.NewRequest(http.MethodPost, "/batch/suggest/", strings.NewReader(c.body))
+		queries, err := decodeBatch(r)
+
+		if (err != nil) != c.isError {
+			t.Errorf("Test fail of %s, expected error %v, got %v", c.name, c.isError, err)
+		}
+
+		if len(queries) != c.size {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.size, len(queries))
+		}
+	}
+}
+
+func TestDecodeBatchBodyLimit(t *testing.T) {
+	body := `[{"dict": "cars", "query": "` + strings.Repeat("a", 128) + `"}]`
+	r := httptest.NewRequest(http.MethodPost, "/batch/suggest/", strings.NewReader(body))
+
+	httputil.LimitBody(64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := decodeBatch(r); err != httputil.ErrBodyTooLarge {
+			t.Errorf("Test fail, expected %v, got %v", httputil.ErrBodyTooLarge, err)
+		}
+	})).ServeHTTP(httptest.NewRecorder(), r)
+}
@@ -1,9 +1,9 @@
 package api
 
 import (
-	"encoding/json"
 	"net/http"
 
+	httputil "github.com/suggest-go/suggest/internal/http"
 	"github.com/suggest-go/suggest/pkg/suggest"
 )
 
@@ -14,17 +14,5 @@ type dictionaryHandler struct {
 
 // handle returns all managed dictionaries by the current suggestService
 func (h *dictionaryHandler) handle(w http.ResponseWriter, r *http.Request) {
-	data, err := json.Marshal(h.suggestService.GetDictionaries())
-
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-
-	if _, err := w.Write(data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	httputil.WriteJSON(w, h.suggestService.GetDictionaries())
 }
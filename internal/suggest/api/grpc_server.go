@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"io"
+
+	"github.com/suggest-go/suggest/api/suggestpb"
+	"github.com/suggest-go/suggest/internal/reindex"
+	"github.com/suggest-go/suggest/pkg/suggest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer implements suggestpb.SuggestServer over the suggest service
+type grpcServer struct {
+	suggestpb.UnimplementedSuggestServer
+	suggestService *suggest.Service
+	coordinator    *reindex.Coordinator
+}
+
+// Suggest performs topK approximate string search
+func (s *grpcServer) Suggest(ctx context.Context, req *suggestpb.SuggestRequest) (*suggestpb.SuggestResponse, error) {
+	searchConf, err := s.suggestService.NewSearchConfig(req.GetDict(), req.GetQuery(), suggest.QueryOptions{
+		TopK:       int(req.GetTopK()),
+		Metric:     req.GetMetric(),
+		Similarity: req.GetSimilarity(),
+	})
+
+	if err == suggest.ErrDictionaryNotFound {
+		return nil, serviceStatus(req.GetDict(), err)
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resultItems, err := s.suggestService.Suggest(req.GetDict(), searchConf)
+
+	if err != nil {
+		return nil, serviceStatus(req.GetDict(), err)
+	}
+
+	return &suggestpb.SuggestResponse{
+		Items: mapResultItems(resultItems),
+	}, nil
+}
+
+// Autocomplete performs autocomplete for the given query
+func (s *grpcServer) Autocomplete(ctx context.Context, req *suggestpb.AutocompleteRequest) (*suggestpb.AutocompleteResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit should be positive integer")
+	}
+
+	resultItems, err := s.suggestService.Autocomplete(req.GetDict(), req.GetQuery(), int(req.GetLimit()))
+
+	if err != nil {
+		return nil, serviceStatus(req.GetDict(), err)
+	}
+
+	return &suggestpb.AutocompleteResponse{
+		Query: req.GetQuery(),
+		Items: mapResultItems(resultItems),
+	}, nil
+}
+
+// StreamAutocomplete performs autocomplete for each query of the stream
+func (s *grpcServer) StreamAutocomplete(stream suggestpb.Suggest_StreamAutocompleteServer) error {
+	for {
+		req, err := stream.Recv()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.Autocomplete(stream.Context(), req)
+
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// ListDictionaries returns all managed dictionaries by the current suggestService
+func (s *grpcServer) ListDictionaries(ctx context.Context, req *suggestpb.ListDictionariesRequest) (*suggestpb.ListDictionariesResponse, error) {
+	return &suggestpb.ListDictionariesResponse{
+		Dictionaries: s.suggestService.GetDictionaries(),
+	}, nil
+}
+
+// Reindex schedules a reindex and returns its job without waiting for the result
+func (s *grpcServer) Reindex(ctx context.Context, req *suggestpb.ReindexRequest) (*suggestpb.ReindexResponse, error) {
+	return mapReindexJob(s.coordinator.Submit()), nil
+}
+
+// GetReindexJob returns the state of the requested reindex job
+func (s *grpcServer) GetReindexJob(ctx context.Context, req *suggestpb.GetReindexJobRequest) (*suggestpb.ReindexResponse, error) {
+	job, err := s.coordinator.Job(req.GetId())
+
+	if err == reindex.ErrJobNotFound {
+		return nil, status.Errorf(codes.NotFound, "reindex job %s is not found", req.GetId())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return mapReindexJob(job), nil
+}
+
+// serviceStatus converts the error returned by the suggest service to the gRPC status
+func serviceStatus(dict string, err error) error {
+	if err == suggest.ErrDictionaryNotFound {
+		return status.Errorf(codes.NotFound, "dictionary %s is not found", dict)
+	}
+
+	if isInvalidArgument(err) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// mapResultItems converts the given result items to the protobuf messages
+func mapResultItems(resultItems []suggest.ResultItem) []*suggestpb.ResultItem {
+	items := make([]*suggestpb.ResultItem, 0, len(resultItems))
+
+	for _, item := range resultItems {
+		items = append(items, &suggestpb.ResultItem{
+			Value: item.Value,
+			Score: item.Score,
+		})
+	}
+
+	return items
+}
+
+// mapReindexJob converts the given reindex job to the protobuf message
+func mapReindexJob(job reindex.Job) *suggestpb.ReindexResponse {
+	return &suggestpb.ReindexResponse{
+		Id:     job.ID,
+		Status: string(job.Status),
+		Done:   int32(job.Done),
+		Total:  int32(job.Total),
+		Error:  job.Error,
+	}
+}
@@ -1,23 +1,44 @@
 package api
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httputil "github.com/suggest-go/suggest/internal/http"
+	"github.com/suggest-go/suggest/internal/reindex"
+)
 
 // reindexHandler is an entity that is responsible for handling reindex requests
 type reindexHandler struct {
-	reindexJob func() error
+	coordinator *reindex.Coordinator
 }
 
-// handle performs reindexJob
+// handle schedules a reindex and replies with its job without waiting for the result
 func (h *reindexHandler) handle(w http.ResponseWriter, r *http.Request) {
-	if err := h.reindexJob(); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	job := h.coordinator.Submit()
+
+	w.Header().Set("Location", "/internal/reindex/"+job.ID+"/")
+	httputil.WriteJSONWithCode(w, http.StatusAccepted, job)
+}
+
+// handleJob replies with the state of the requested reindex job
+func (h *reindexHandler) handleJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.coordinator.Job(mux.Vars(r)["id"])
+
+	if err == reindex.ErrJobNotFound {
+		httputil.WriteError(w, http.StatusNotFound, err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/plain")
-
-	if _, err := w.Write([]byte("OK")); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err != nil {
+		httputil.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+
+	httputil.WriteJSON(w, job)
+}
+
+// handleList replies with the recent reindex jobs
+func (h *reindexHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	httputil.WriteJSON(w, h.coordinator.Jobs())
 }
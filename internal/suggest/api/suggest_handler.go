@@ -1,38 +1,14 @@
 package api
 
 import (
-	"encoding/json"
-	"errors"
-	"github.com/gorilla/mux"
+	"fmt"
+	"net/http"
+
 	httputil "github.com/suggest-go/suggest/internal/http"
 	"github.com/suggest-go/suggest/pkg/metric"
 	"github.com/suggest-go/suggest/pkg/suggest"
-	"net/http"
-)
-
-const (
-	jaccard = "Jaccard"
-	cosine  = "Cosine"
-	dice    = "Dice"
-	exact   = "Exact"
-	overlap = "Overlap"
-
-	defaultSimilarity = 0.5
-	defaultTopK = 5
 )
 
-var metrics map[string]metric.Metric
-
-func init() {
-	metrics = map[string]metric.Metric{
-		jaccard: metric.JaccardMetric(),
-		cosine:  metric.CosineMetric(),
-		dice:    metric.DiceMetric(),
-		exact:   metric.ExactMetric(),
-		overlap: metric.OverlapMetric(),
-	}
-}
-
 // suggestHandler responses for handling suggest requests
 type suggestHandler struct {
 	suggestService *suggest.Service
@@ -40,62 +16,76 @@ type suggestHandler struct {
 
 // handle performs topK approximate string search
 func (h *suggestHandler) handle(w http.ResponseWriter, r *http.Request) {
-	var (
-		vars       = mux.Vars(r)
-		dict       = vars["dict"]
-	)
-
-	searchConf, err := buildSearchConfig(r)
+	dict, err := httputil.PathValue(r, "dict")
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// TODO return 4** on dictionary not found
-	resultItems, err := h.suggestService.Suggest(dict, searchConf)
+	searchConf, err := h.buildSearchConfig(dict, r)
 
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err == suggest.ErrDictionaryNotFound {
+		writeServiceError(w, dict, err)
 		return
 	}
 
-	data, err := json.Marshal(resultItems)
-
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	resultItems, err := h.suggestService.Suggest(dict, searchConf)
 
-	if _, err := w.Write(data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err != nil {
+		writeServiceError(w, dict, err)
 		return
 	}
+
+	httputil.WriteJSON(w, resultItems)
 }
 
-// buildSearchConfig builds a search config for the given list of parameters
-func buildSearchConfig(r *http.Request) (suggest.SearchConfig, error) {
-	vars := mux.Vars(r)
-	topK, err := httputil.FormTopKValue(r, "topK", defaultTopK)
+// buildSearchConfig builds a search config for the given list of parameters, the omitted
+// parameters are set to the defaults of the dictionary
+func (h *suggestHandler) buildSearchConfig(dict string, r *http.Request) (suggest.SearchConfig, error) {
+	query, err := httputil.QueryValue(r)
 
 	if err != nil {
 		return suggest.SearchConfig{}, err
 	}
 
-	metricName := r.FormValue("metric")
-	m, ok := metrics[metricName]
+	topK, err := httputil.FormTopKValue(r, "topK", 0)
 
-	if !ok {
-		return suggest.SearchConfig{}, errors.New("metric is not found")
+	if err != nil {
+		return suggest.SearchConfig{}, err
 	}
 
-	similarity, err := httputil.FormSimilarityValue(r, "similarity", defaultSimilarity)
+	similarity, err := httputil.FormSimilarityValue(r, "similarity", 0)
 
 	if err != nil {
 		return suggest.SearchConfig{}, err
 	}
 
-	return suggest.NewSearchConfig(vars["query"], topK, m, similarity)
+	return h.suggestService.NewSearchConfig(dict, query, suggest.QueryOptions{
+		TopK:       topK,
+		Metric:     r.FormValue("metric"),
+		Similarity: similarity,
+	})
+}
+
+// writeServiceError writes the error returned by the suggest service
+func writeServiceError(w http.ResponseWriter, dict string, err error) {
+	switch {
+	case err == suggest.ErrDictionaryNotFound:
+		httputil.WriteError(w, http.StatusNotFound, fmt.Sprintf("dictionary %s is not found", dict))
+	case isInvalidArgument(err):
+		httputil.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputil.WriteError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// isInvalidArgument tells whether the error returned by the suggest service is caused by the query
+func isInvalidArgument(err error) bool {
+	return err == suggest.ErrTopKLimit || err == suggest.ErrQueryLengthLimit || err == metric.ErrMetricNotFound
 }
@@ -0,0 +1,47 @@
+// Package version provides the build information of the binaries
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// The variables are set at the build time, e.g.
+// go build -ldflags "-X github.com/suggest-go/suggest/internal/version.Version=v1.0.0"
+var (
+	// Version is the version of the build
+	Version = ""
+	// Commit is the revision of the build
+	Commit = ""
+	// BuildDate is the date of the build
+	BuildDate = ""
+)
+
+// Info is the build information
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"buildDate,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the build information. If the version is not set at the build time,
+// the version of the main module is used
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+
+		if buildInfo, ok := debug.ReadBuildInfo(); ok && buildInfo.Main.Version != "" {
+			info.Version = buildInfo.Main.Version
+		}
+	}
+
+	return info
+}
@@ -1,6 +1,13 @@
 // Package alphabet provides API for manipulating with the list of defined characters
 package alphabet
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
 // Alphabet is abstract for manipulating with set of symbols
 type Alphabet interface {
 	// Has tells is given char exists in alphabet
@@ -13,28 +20,125 @@ type Alphabet interface {
 
 var (
 	alphabetMap = map[string]Alphabet{
-		"english": NewEnglishAlphabet(),
-		"russian": NewRussianAlphabet(),
-		"numbers": NewNumberAlphabet(),
+		"english":   NewEnglishAlphabet(),
+		"russian":   NewRussianAlphabet(),
+		"numbers":   NewNumberAlphabet(),
+		"german":    NewGermanAlphabet(),
+		"french":    NewFrenchAlphabet(),
+		"spanish":   NewSpanishAlphabet(),
+		"ukrainian": NewUkrainianAlphabet(),
+		"turkish":   NewTurkishAlphabet(),
 	}
 )
 
-// CreateAlphabet creates alphabet from a string declaration
+const (
+	scriptPrefix   = "script:"
+	categoryPrefix = "category:"
+	codePrefix     = "U+"
+)
+
+// CreateAlphabet creates alphabet from a string declaration. A declaration is either a built-in alphabet
+// (e.g. "english"), an unicode script ("script:Greek"), an unicode category ("category:L"), a range of code
+// points ("U+0600-U+06FF") or a list of the characters. The declarations are validated by ParseAlphabet,
+// an invalid one is taken as a list of the characters
 func CreateAlphabet(description []string) Alphabet {
 	alphabets := make([]Alphabet, 0)
 
 	for _, symbols := range description {
-		if alphabet, ok := alphabetMap[symbols]; ok {
-			alphabets = append(alphabets, alphabet)
-			continue
+		alphabet, err := parseDeclaration(symbols)
+
+		if err != nil {
+			alphabet = NewSimpleAlphabet([]rune(symbols))
 		}
 
-		alphabets = append(alphabets, NewSimpleAlphabet([]rune(symbols)))
+		alphabets = append(alphabets, alphabet)
 	}
 
 	return NewCompositeAlphabet(alphabets)
 }
 
+// ParseAlphabet creates alphabet from a string declaration, see CreateAlphabet, and reports the invalid declarations
+func ParseAlphabet(description []string) (Alphabet, error) {
+	alphabets := make([]Alphabet, 0)
+
+	for _, symbols := range description {
+		alphabet, err := parseDeclaration(symbols)
+
+		if err != nil {
+			return nil, err
+		}
+
+		alphabets = append(alphabets, alphabet)
+	}
+
+	return NewCompositeAlphabet(alphabets), nil
+}
+
+// parseDeclaration creates the alphabet of a single declaration
+func parseDeclaration(symbols string) (Alphabet, error) {
+	if alphabet, ok := alphabetMap[symbols]; ok {
+		return alphabet, nil
+	}
+
+	switch {
+	case strings.HasPrefix(symbols, scriptPrefix):
+		name := strings.TrimPrefix(symbols, scriptPrefix)
+		table, ok := unicode.Scripts[name]
+
+		if !ok {
+			return nil, fmt.Errorf("unknown unicode script %s", name)
+		}
+
+		return NewUnicodeAlphabet(table), nil
+	case strings.HasPrefix(symbols, categoryPrefix):
+		name := strings.TrimPrefix(symbols, categoryPrefix)
+		table, ok := unicode.Categories[name]
+
+		if !ok {
+			return nil, fmt.Errorf("unknown unicode category %s", name)
+		}
+
+		return NewUnicodeAlphabet(table), nil
+	case strings.HasPrefix(symbols, codePrefix) && len(symbols) > len(codePrefix):
+		return parseRange(symbols)
+	}
+
+	return NewSimpleAlphabet([]rune(symbols)), nil
+}
+
+// parseRange creates the alphabet of the code points range, e.g. "U+0600-U+06FF" or "U+00E9"
+func parseRange(symbols string) (Alphabet, error) {
+	bounds := strings.Split(symbols, "-")
+
+	if len(bounds) > 2 {
+		return nil, fmt.Errorf("invalid code points range %s", symbols)
+	}
+
+	points := make([]rune, 0, 2)
+
+	for _, bound := range bounds {
+		if !strings.HasPrefix(bound, codePrefix) {
+			return nil, fmt.Errorf("invalid code point %s of the range %s", bound, symbols)
+		}
+
+		point, err := strconv.ParseUint(strings.TrimPrefix(bound, codePrefix), 16, 32)
+
+		if err != nil || point > unicode.MaxRune {
+			return nil, fmt.Errorf("invalid code point %s of the range %s", bound, symbols)
+		}
+
+		points = append(points, rune(point))
+	}
+
+	min, max := points[0], points[len(points)-1]
+
+	if min > max {
+		return nil, fmt.Errorf("invalid code points range %s, the start is greater than the end", symbols)
+	}
+
+	return NewSequentialAlphabet(min, max), nil
+}
+
 type charHolder struct {
 	chars []rune
 }
@@ -61,6 +61,79 @@ func TestCompositeAlphabet(t *testing.T) {
 	}
 }
 
+func TestCreateAlphabet(t *testing.T) {
+	cases := []struct {
+		description []string
+		char        rune
+		expected    bool
+	}{
+		{[]string{"script:Greek"}, 'λ', true},
+		{[]string{"script:Greek"}, 'l', false},
+		{[]string{"category:L"}, 'ß', true},
+		{[]string{"category:L"}, '漢', true},
+		{[]string{"category:Nd"}, '٣', true},
+		{[]string{"category:L"}, '-', false},
+		{[]string{"U+0600-U+06FF"}, 'ب', true},
+		{[]string{"U+0600-U+06FF"}, 'b', false},
+		{[]string{"U+00E9"}, 'é', true},
+		{[]string{"german"}, 'ß', true},
+		{[]string{"german"}, 'é', false},
+		{[]string{"french"}, 'œ', true},
+		{[]string{"spanish"}, 'ñ', true},
+		{[]string{"turkish"}, 'ı', true},
+		{[]string{"ukrainian"}, 'ї', true},
+		{[]string{"ukrainian"}, 'ы', false},
+		{[]string{"english", "-."}, '.', true},
+		{[]string{"script:Unknown"}, 'U', true},
+	}
+
+	for _, c := range cases {
+		actual := CreateAlphabet(c.description).Has(c.char)
+
+		if c.expected != actual {
+			t.Errorf("Test Fail, expected %v, got %v for %c in %v", c.expected, actual, c.char, c.description)
+		}
+	}
+}
+
+func TestParseAlphabet(t *testing.T) {
+	cases := []struct {
+		description []string
+		fail        bool
+	}{
+		{[]string{"english", "script:Cyrillic", "category:Lu", "U+0600-U+06FF", "-."}, false},
+		{[]string{"script:Unknown"}, true},
+		{[]string{"category:Unknown"}, true},
+		{[]string{"U+06FF-U+0600"}, true},
+		{[]string{"U+XYZ"}, true},
+		{[]string{"U+0600-0700"}, true},
+		{[]string{"U+110000"}, true},
+	}
+
+	for _, c := range cases {
+		_, err := ParseAlphabet(c.description)
+
+		if (err != nil) != c.fail {
+			t.Errorf("Test Fail, expected fail %v, got %v for %v", c.fail, err, c.description)
+		}
+	}
+}
+
+func TestUnicodeAlphabet(t *testing.T) {
+	alphabet := CreateAlphabet([]string{"script:Greek"})
+	chars := alphabet.Chars()
+
+	if len(chars) != alphabet.Size() {
+		t.Errorf("Test Fail, expected %v, got %v", alphabet.Size(), len(chars))
+	}
+
+	for _, char := range chars {
+		if !alphabet.Has(char) {
+			t.Errorf("Test Fail, expected %c to be in the alphabet", char)
+		}
+	}
+}
+
 func BenchmarkHas(b *testing.B) {
 	ngram := "ёj9"
 	alphabet := NewCompositeAlphabet(
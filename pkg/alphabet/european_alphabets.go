@@ -0,0 +1,42 @@
+package alphabet
+
+// extendedLatinAlphabet represents english alphabet with the additional letters of a language
+type extendedLatinAlphabet struct {
+	Alphabet
+}
+
+// newExtendedLatinAlphabet returns the composition of english alphabet and the given letters
+func newExtendedLatinAlphabet(letters string) Alphabet {
+	return &extendedLatinAlphabet{
+		NewCompositeAlphabet([]Alphabet{
+			NewEnglishAlphabet(),
+			NewSimpleAlphabet([]rune(letters)),
+		}),
+	}
+}
+
+// NewGermanAlphabet returns new instance of german alphabet a-z, äöüß
+func NewGermanAlphabet() Alphabet {
+	return newExtendedLatinAlphabet("äöüß")
+}
+
+// NewFrenchAlphabet returns new instance of french alphabet a-z, àâæçéèêëîïôœùûüÿ
+func NewFrenchAlphabet() Alphabet {
+	return newExtendedLatinAlphabet("àâæçéèêëîïôœùûüÿ")
+}
+
+// NewSpanishAlphabet returns new instance of spanish alphabet a-z, áéíñóúü
+func NewSpanishAlphabet() Alphabet {
+	return newExtendedLatinAlphabet("áéíñóúü")
+}
+
+// NewTurkishAlphabet returns new instance of turkish alphabet a-z, çğıöşü. The letters q, w and x
+// are kept for the foreign names
+func NewTurkishAlphabet() Alphabet {
+	return newExtendedLatinAlphabet("çğıöşü")
+}
+
+// NewUkrainianAlphabet returns new instance of ukrainian alphabet
+func NewUkrainianAlphabet() Alphabet {
+	return NewSimpleAlphabet([]rune("абвгґдеєжзиіїйклмнопрстуфхцчшщьюя"))
+}
@@ -0,0 +1,57 @@
+package alphabet
+
+import "unicode"
+
+// unicodeAlphabet represents the characters of an unicode script or category
+type unicodeAlphabet struct {
+	table *unicode.RangeTable
+	size  int
+}
+
+// NewUnicodeAlphabet returns new instance of unicodeAlphabet of the given range table,
+// e.g. unicode.Greek or unicode.L
+func NewUnicodeAlphabet(table *unicode.RangeTable) Alphabet {
+	size := 0
+
+	for _, r := range table.R16 {
+		size += int((r.Hi-r.Lo)/r.Stride) + 1
+	}
+
+	for _, r := range table.R32 {
+		size += int((r.Hi-r.Lo)/r.Stride) + 1
+	}
+
+	return &unicodeAlphabet{
+		table: table,
+		size:  size,
+	}
+}
+
+// Has tells is given char exists in alphabet
+func (a *unicodeAlphabet) Has(char rune) bool {
+	return unicode.Is(a.table, char)
+}
+
+// Size returns the size of alphabet
+func (a *unicodeAlphabet) Size() int {
+	return a.size
+}
+
+// Chars returns the current set of symbols, they are enumerated on each call
+func (a *unicodeAlphabet) Chars() []rune {
+	chars := make([]rune, 0, a.size)
+
+	for _, r := range a.table.R16 {
+		for ch := rune(r.Lo); ch <= rune(r.Hi); ch += rune(r.Stride) {
+			chars = append(chars, ch)
+		}
+	}
+
+	for _, r := range a.table.R32 {
+		for ch := rune(r.Lo); ch <= rune(r.Hi); ch += rune(r.Stride) {
+			chars = append(chars, ch)
+		}
+	}
+
+	return chars
+}
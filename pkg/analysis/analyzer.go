@@ -0,0 +1,470 @@
+package analysis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/suggest-go/suggest/pkg/alphabet"
+)
+
+// AnalyzerStep is a named tokenizer or filter of an analyzer chain with its parameters
+type AnalyzerStep struct {
+	// Type is the name of the tokenizer or the filter in the registry
+	Type string `json:"type"`
+	// Params are the parameters of the step, the format is defined by the factory
+	Params json.RawMessage `json:"params"`
+}
+
+// AnalyzerConfig describes an analyzer as an ordered list of steps. The text enters the chain
+// as a single token, a tokenizer splits each token of the flow and a filter alters the flow
+type AnalyzerConfig struct {
+	Steps []AnalyzerStep `json:"steps"`
+}
+
+// TokenizerFactory creates a tokenizer of an analyzer step with the given parameters
+type TokenizerFactory func(params json.RawMessage) (Tokenizer, error)
+
+// TokenFilterFactory creates a filter of an analyzer step with the given parameters
+type TokenFilterFactory func(params json.RawMessage) (TokenFilter, error)
+
+// Registry resolves the steps of the analyzers by their names
+type Registry struct {
+	sync.RWMutex
+	tokenizers map[string]TokenizerFactory
+	filters    map[string]TokenFilterFactory
+}
+
+// DefaultRegistry is the registry of the built-in steps used by the index descriptions
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates a registry with the built-in tokenizers and filters
+func NewRegistry() *Registry {
+	return &Registry{
+		tokenizers: map[string]TokenizerFactory{
+			"word":  newWordStep,
+			"ngram": newNGramStep,
+		},
+		filters: map[string]TokenFilterFactory{
+			"lowercase": newLowercaseStep,
+			"normalize": newNormalizeStep,
+			"alphabet":  newAlphabetStep,
+			"wrap":      newWrapStep,
+			"stopwords": newStopWordsStep,
+			"stem":      newStemStep,
+			"synonyms":  newSynonymsStep,
+		},
+	}
+}
+
+// RegisterTokenizer adds the tokenizer factory with the given name to the registry
+func (r *Registry) RegisterTokenizer(name string, factory TokenizerFactory) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+
+	r.tokenizers[name] = factory
+
+	return nil
+}
+
+// RegisterFilter adds the filter factory with the given name to the registry
+func (r *Registry) RegisterFilter(name string, factory TokenFilterFactory) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if err := r.checkName(name); err != nil {
+		return err
+	}
+
+	r.filters[name] = factory
+
+	return nil
+}
+
+// NewAnalyzer creates a tokenizer of the given analyzer chain
+func (r *Registry) NewAnalyzer(config AnalyzerConfig) (Tokenizer, error) {
+	if len(config.Steps) == 0 {
+		return nil, errors.New("analyzer has no steps")
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+
+	steps := make([]TokenFilter, 0, len(config.Steps))
+
+	for _, step := range config.Steps {
+		filter, err := r.newStep(step)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create analyzer step %s: %v", step.Type, err)
+		}
+
+		steps = append(steps, filter)
+	}
+
+	return &analyzer{steps: steps}, nil
+}
+
+// newStep creates the tokenizer or the filter of the step, a tokenizer is applied to each token
+func (r *Registry) newStep(step AnalyzerStep) (TokenFilter, error) {
+	if factory, ok := r.tokenizers[step.Type]; ok {
+		tokenizer, err := factory(step.Params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &tokenizerFilter{tokenizer: tokenizer}, nil
+	}
+
+	if factory, ok := r.filters[step.Type]; ok {
+		return factory(step.Params)
+	}
+
+	return nil, errors.New("unknown step")
+}
+
+// checkName checks that the name is not taken by a tokenizer or a filter
+func (r *Registry) checkName(name string) error {
+	if name == "" {
+		return errors.New("step name is empty")
+	}
+
+	_, isTokenizer := r.tokenizers[name]
+	_, isFilter := r.filters[name]
+
+	if isTokenizer || isFilter {
+		return fmt.Errorf("step %s is already registered", name)
+	}
+
+	return nil
+}
+
+// RegisterTokenizer adds the tokenizer factory with the given name to the default registry
+func RegisterTokenizer(name string, factory TokenizerFactory) error {
+	return DefaultRegistry.RegisterTokenizer(name, factory)
+}
+
+// RegisterFilter adds the filter factory with the given name to the default registry
+func RegisterFilter(name string, factory TokenFilterFactory) error {
+	return DefaultRegistry.RegisterFilter(name, factory)
+}
+
+// NewAnalyzer creates a tokenizer of the given analyzer chain with the default registry
+func NewAnalyzer(config AnalyzerConfig) (Tokenizer, error) {
+	return DefaultRegistry.NewAnalyzer(config)
+}
+
+// analyzer passes the text through the steps of the chain
+type analyzer struct {
+	steps []TokenFilter
+}
+
+// Tokenize splits the given text on a sequence of unique tokens
+func (a *analyzer) Tokenize(text string) []Token {
+	tokens := []Token{text}
+
+	for _, step := range a.steps {
+		tokens = step.Filter(tokens)
+	}
+
+	result := make([]Token, 0, len(tokens))
+
+	for _, token := range tokens {
+		result = appendUnique(result, token)
+	}
+
+	return result
+}
+
+// tokenizerFilter splits each token of the flow with the tokenizer
+type tokenizerFilter struct {
+	tokenizer Tokenizer
+}
+
+// Filter filters the given list with described behaviour
+func (f *tokenizerFilter) Filter(list []Token) []Token {
+	result := make([]Token, 0, len(list))
+
+	for _, token := range list {
+		result = append(result, f.tokenizer.Tokenize(token)...)
+	}
+
+	return result
+}
+
+// funcFilter applies the function to each token of the flow and skips the empty results
+type funcFilter func(token Token) Token
+
+// Filter filters the given list with described behaviour
+func (f funcFilter) Filter(list []Token) []Token {
+	result := make([]Token, 0, len(list))
+
+	for _, token := range list {
+		if token = f(token); token != "" {
+			result = append(result, token)
+		}
+	}
+
+	return result
+}
+
+// readParams unmarshals the parameters of a step, the missing parameters keep the values of params
+func readParams(data json.RawMessage, params interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, params); err != nil {
+		return fmt.Errorf("failed to unmarshal params: %v", err)
+	}
+
+	return nil
+}
+
+// newWordStep creates the tokenizer that splits a text on words of the alphabet, on letters and digits by default
+func newWordStep(data json.RawMessage) (Tokenizer, error) {
+	params := struct {
+		Alphabet []string `json:"alphabet"`
+	}{}
+
+	if err := readParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	if len(params.Alphabet) == 0 {
+		return TokenizerFunc(func(text string) []Token {
+			return strings.FieldsFunc(text, func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+			})
+		}), nil
+	}
+
+	chars, err := alphabet.ParseAlphabet(params.Alphabet)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWordTokenizer(chars), nil
+}
+
+// newNGramStep creates the tokenizer that splits a text on the n-grams of the given size or range
+func newNGramStep(data json.RawMessage) (Tokenizer, error) {
+	params := struct {
+		Size int `json:"size"`
+		Min  int `json:"min"`
+		Max  int `json:"max"`
+	}{}
+
+	if err := readParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	if params.Size > 0 {
+		params.Min, params.Max = params.Size, params.Size
+	}
+
+	if params.Min < 1 || params.Max < params.Min || params.Max > maxN {
+		return nil, fmt.Errorf("n-gram size should be in range [1, %d]", maxN)
+	}
+
+	if params.Min == params.Max {
+		return NewNGramTokenizer(params.Min), nil
+	}
+
+	tokenizers := make([]Tokenizer, 0, params.Max-params.Min+1)
+
+	for n := params.Min; n <= params.Max; n++ {
+		tokenizers = append(tokenizers, NewNGramTokenizer(n))
+	}
+
+	return TokenizerFunc(func(text string) []Token {
+		result := []Token{}
+
+		for _, tokenizer := range tokenizers {
+			result = append(result, tokenizer.Tokenize(text)...)
+		}
+
+		return result
+	}), nil
+}
+
+// newLowercaseStep creates the filter that lowercases and trims the tokens
+func newLowercaseStep(data json.RawMessage) (TokenFilter, error) {
+	return funcFilter(func(token Token) Token {
+		return strings.TrimSpace(strings.ToLower(token))
+	}), nil
+}
+
+// newNormalizeStep creates the filter that normalizes the tokens with the normalization pipeline
+func newNormalizeStep(data json.RawMessage) (TokenFilter, error) {
+	config := NormalizationConfig{}
+
+	if err := readParams(data, &config); err != nil {
+		return nil, err
+	}
+
+	normalizer, err := NewNormalizer(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return funcFilter(normalizer.Normalize), nil
+}
+
+// newAlphabetStep creates the filter that replaces the characters out of the alphabet with the pad
+func newAlphabetStep(data json.RawMessage) (TokenFilter, error) {
+	params := struct {
+		Alphabet []string `json:"alphabet"`
+		Pad      string   `json:"pad"`
+	}{}
+
+	if err := readParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	if len(params.Alphabet) == 0 {
+		return nil, errors.New("alphabet is empty")
+	}
+
+	chars, err := alphabet.ParseAlphabet(params.Alphabet)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNormalizerFilter(chars, params.Pad), nil
+}
+
+// newWrapStep creates the filter that wraps each token with the start and the end symbols
+func newWrapStep(data json.RawMessage) (TokenFilter, error) {
+	params := struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}{}
+
+	if err := readParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	return funcFilter(func(token Token) Token {
+		return params.Start + token + params.End
+	}), nil
+}
+
+// newStopWordsStep creates the filter that removes the given words
+func newStopWordsStep(data json.RawMessage) (TokenFilter, error) {
+	params := struct {
+		Words []string `json:"words"`
+	}{}
+
+	if err := readParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	stopWords := make(map[string]bool, len(params.Words))
+
+	for _, word := range params.Words {
+		stopWords[word] = true
+	}
+
+	return funcFilter(func(token Token) Token {
+		if stopWords[token] {
+			return ""
+		}
+
+		return token
+	}), nil
+}
+
+// newStemStep creates the filter that replaces the tokens with their stems of the given language
+func newStemStep(data json.RawMessage) (TokenFilter, error) {
+	params := struct {
+		Language string `json:"language"`
+	}{}
+
+	if err := readParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	stemmer, err := NewStemmer(params.Language)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStemFilter(stemmer), nil
+}
+
+// newSynonymsStep creates the filter that adds the synonyms of each token to the flow. The synonyms are
+// read from the file of the path, see ReadSynonyms for the format, and from the one-way mapping of the map
+func newSynonymsStep(data json.RawMessage) (TokenFilter, error) {
+	params := struct {
+		Path string              `json:"path"`
+		Map  map[string][]string `json:"map"`
+	}{}
+
+	if err := readParams(data, &params); err != nil {
+		return nil, err
+	}
+
+	if params.Path == "" && len(params.Map) == 0 {
+		return nil, errors.New("synonyms path or map should be set")
+	}
+
+	synonyms := Synonyms{}
+
+	if params.Path != "" {
+		fileSynonyms, err := ReadSynonyms(params.Path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		synonyms = fileSynonyms
+	}
+
+	for phrase, alternatives := range params.Map {
+		targets := splitPhrases(strings.Join(alternatives, ","))
+
+		for _, source := range splitPhrases(phrase) {
+			synonyms.add(source, targets)
+		}
+	}
+
+	return &synonymsFilter{synonyms: synonyms}, nil
+}
+
+// synonymsFilter appends the synonyms of each token after the token
+type synonymsFilter struct {
+	synonyms Synonyms
+}
+
+// Filter filters the given list with described behaviour
+func (f *synonymsFilter) Filter(list []Token) []Token {
+	result := make([]Token, 0, len(list))
+
+	for _, token := range list {
+		result = append(result, token)
+		result = append(result, f.synonyms[strings.ToLower(token)]...)
+	}
+
+	return result
+}
+
+// TokenizerFunc is an adapter to use an ordinary function as a Tokenizer
+type TokenizerFunc func(text string) []Token
+
+// Tokenize splits the given text on a sequence of tokens
+func (f TokenizerFunc) Tokenize(text string) []Token {
+	return f(text)
+}
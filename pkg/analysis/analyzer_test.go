@@ -0,0 +1,144 @@
+package analysis
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzer(t *testing.T) {
+	cases := []struct {
+		config   string
+		text     string
+		expected []Token
+	}{
+		{
+			`{"steps": [{"type": "lowercase"}, {"type": "wrap", "params": {"start": "$", "end": "$"}}, {"type": "ngram", "params": {"size": 3}}]}`,
+			"Kia",
+			[]Token{"$ki", "kia", "ia$"},
+		},
+		{
+			`{"steps": [{"type": "word"}, {"type": "lowercase"}, {"type": "stopwords", "params": {"words": ["the", "of"]}}]}`,
+			"The Lord of the Rings",
+			[]Token{"lord", "rings"},
+		},
+		{
+			`{"steps": [{"type": "word"}, {"type": "ngram", "params": {"min": 1, "max": 2}}]}`,
+			"ab ba",
+			[]Token{"a", "b", "ab", "ba"},
+		},
+		{
+			`{"steps": [{"type": "alphabet", "params": {"alphabet": ["english"], "pad": "$"}}, {"type": "ngram", "params": {"size": 2}}]}`,
+			"a-b",
+			[]Token{"a$", "$b"},
+		},
+		{
+			`{"steps": [{"type": "normalize", "params": {"steps": ["stripAccents"]}}, {"type": "word"}]}`,
+			"café naïve",
+			[]Token{"cafe", "naive"},
+		},
+		{
+			`{"steps": [{"type": "word"}, {"type": "lowercase"}, {"type": "synonyms", "params": {"map": {"chevy": ["chevrolet"]}}}]}`,
+			"Chevy Camaro",
+			[]Token{"chevy", "chevrolet", "camaro"},
+		},
+		{
+			`{"steps": [{"type": "word"}, {"type": "synonyms", "params": {"path": "testdata/synonyms.txt"}}]}`,
+			"vw golf",
+			[]Token{"vw", "volkswagen", "golf"},
+		},
+	}
+
+	for _, c := range cases {
+		config := AnalyzerConfig{}
+
+		if err := json.Unmarshal([]byte(c.config), &config); err != nil {
+			t.Fatal(err)
+		}
+
+		analyzer, err := NewAnalyzer(config)
+
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+			continue
+		}
+
+		actual := analyzer.Tokenize(c.text)
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Test fail, expected %v, got %v", c.expected, actual)
+		}
+	}
+}
+
+func TestAnalyzerValidation(t *testing.T) {
+	cases := []AnalyzerConfig{
+		{},
+		{Steps: []AnalyzerStep{{Type: "unknown"}}},
+		{Steps: []AnalyzerStep{{Type: "ngram"}}},
+		{Steps: []AnalyzerStep{{Type: "ngram", Params: json.RawMessage(`{"min": 3, "max": 2}`)}}},
+		{Steps: []AnalyzerStep{{Type: "alphabet"}}},
+		{Steps: []AnalyzerStep{{Type: "normalize", Params: json.RawMessage(`{"steps": ["unknown"]}`)}}},
+		{Steps: []AnalyzerStep{{Type: "synonyms"}}},
+		{Steps: []AnalyzerStep{{Type: "synonyms", Params: json.RawMessage(`{"path": "testdata/unknown.txt"}`)}}},
+	}
+
+	for _, config := range cases {
+		if _, err := NewAnalyzer(config); err == nil {
+			t.Errorf("Test fail, expected error for %v", config)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	reverse := func(params json.RawMessage) (TokenFilter, error) {
+		return funcFilter(func(token Token) Token {
+			runes := []rune(token)
+
+			for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+				runes[i], runes[j] = runes[j], runes[i]
+			}
+
+			return string(runes)
+		}), nil
+	}
+
+	if err := registry.RegisterFilter("reverse", reverse); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := registry.RegisterFilter("reverse", reverse); err == nil {
+		t.Errorf("Test fail, expected error for a duplicated filter")
+	}
+
+	if err := registry.RegisterTokenizer("ngram", nil); err == nil {
+		t.Errorf("Test fail, expected error for a built-in tokenizer")
+	}
+
+	if err := registry.RegisterTokenizer("fields", func(params json.RawMessage) (Tokenizer, error) {
+		return TokenizerFunc(strings.Fields), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	analyzer, err := registry.NewAnalyzer(AnalyzerConfig{
+		Steps: []AnalyzerStep{{Type: "fields"}, {Type: "reverse"}},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Token{"olleh", "dlrow"}
+	actual := analyzer.Tokenize("hello world")
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Test fail, expected %v, got %v", expected, actual)
+	}
+
+	if _, err := NewAnalyzer(AnalyzerConfig{Steps: []AnalyzerStep{{Type: "reverse"}}}); err == nil {
+		t.Errorf("Test fail, expected the default registry to be unaffected")
+	}
+}
@@ -0,0 +1,303 @@
+package analysis
+
+import "strings"
+
+// englishStemmer implements the Porter2 (Snowball english) stemming algorithm
+// https://snowballstem.org/algorithms/english/stemmer.html
+type englishStemmer struct{}
+
+// suffixRule replaces the suffix of a word
+type suffixRule struct {
+	suffix, replacement string
+}
+
+// englishExceptions are the words with the irregular stems
+var englishExceptions = map[string]string{
+	"skis": "ski", "skies": "sky", "dying": "die", "lying": "lie", "tying": "tie",
+	"idly": "idl", "gently": "gentl", "ugly": "ugli", "early": "earli", "only": "onli", "singly": "singl",
+	"sky": "sky", "news": "news", "howe": "howe", "atlas": "atlas", "cosmos": "cosmos", "bias": "bias",
+	"andes": "andes",
+}
+
+// englishInvariants are the words that are left as is after the step 1a
+var englishInvariants = map[string]bool{
+	"inning": true, "outing": true, "canning": true, "herring": true,
+	"earring": true, "proceed": true, "exceed": true, "succeed": true,
+}
+
+// the rules of the steps are ordered by the suffix length, the longest matched suffix is applied
+var (
+	englishStep2Rules = []suffixRule{
+		{"ization", "ize"}, {"ational", "ate"}, {"fulness", "ful"}, {"ousness", "ous"}, {"iveness", "ive"},
+		{"tional", "tion"}, {"biliti", "ble"}, {"lessli", "less"},
+		{"entli", "ent"}, {"ation", "ate"}, {"alism", "al"}, {"aliti", "al"}, {"ousli", "ous"},
+		{"iviti", "ive"}, {"fulli", "ful"},
+		{"enci", "ence"}, {"anci", "ance"}, {"abli", "able"}, {"izer", "ize"}, {"ator", "ate"}, {"alli", "al"},
+		{"bli", "ble"}, {"ogi", "og"},
+		{"li", ""},
+	}
+	englishStep3Rules = []suffixRule{
+		{"ational", "ate"}, {"tional", "tion"}, {"alize", "al"}, {"icate", "ic"}, {"iciti", "ic"},
+		{"ative", ""}, {"ical", "ic"}, {"ness", ""}, {"ful", ""},
+	}
+	englishStep4Suffixes = []string{
+		"ement", "ance", "ence", "able", "ible", "ment", "ant", "ent", "ism", "ate", "iti", "ous", "ive", "ize",
+		"ion", "al", "er", "ic",
+	}
+)
+
+// NewEnglishStemmer returns the Porter2 stemmer
+func NewEnglishStemmer() Stemmer {
+	return &englishStemmer{}
+}
+
+// Stem returns the stem of the lowercased word, the words with the non latin letters are left as is
+func (s *englishStemmer) Stem(word string) string {
+	if len(word) <= 2 || strings.IndexFunc(word, isNotEnglish) >= 0 {
+		return word
+	}
+
+	if stem, ok := englishExceptions[word]; ok {
+		return stem
+	}
+
+	word = strings.TrimPrefix(word, "'")
+	w := []byte(word)
+
+	for i, c := range w {
+		if c == 'y' && (i == 0 || isEnglishVowel(w[i-1])) {
+			w[i] = 'Y'
+		}
+	}
+
+	word = string(w)
+	r1 := englishRegion(word, 0)
+
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(word, prefix) {
+			r1 = len(prefix)
+			break
+		}
+	}
+
+	r2 := englishRegion(word, r1)
+
+	word = englishStep0(word)
+	word = englishStep1a(word)
+
+	if englishInvariants[word] {
+		return word
+	}
+
+	word = englishStep1b(word, r1)
+	word = englishStep1c(word)
+	word = englishStep2And3(word, englishStep2Rules, r1, r2)
+	word = englishStep2And3(word, englishStep3Rules, r1, r2)
+	word = englishStep4(word, r2)
+	word = englishStep5(word, r1, r2)
+
+	return strings.Replace(word, "Y", "y", -1)
+}
+
+// englishStep0 removes the possessive suffixes
+func englishStep0(word string) string {
+	for _, suffix := range []string{"'s'", "'s", "'"} {
+		if strings.HasSuffix(word, suffix) {
+			return word[:len(word)-len(suffix)]
+		}
+	}
+
+	return word
+}
+
+// englishStep1a removes the plural suffixes
+func englishStep1a(word string) string {
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ied"), strings.HasSuffix(word, "ies"):
+		if len(word) > 4 {
+			return word[:len(word)-2]
+		}
+
+		return word[:len(word)-1]
+	case strings.HasSuffix(word, "us"), strings.HasSuffix(word, "ss"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		if hasEnglishVowel(word[:len(word)-2]) {
+			return word[:len(word)-1]
+		}
+	}
+
+	return word
+}
+
+// englishStep1b removes the suffixes of the past and the continuous forms
+func englishStep1b(word string, r1 int) string {
+	for _, suffix := range []string{"eedly", "ingly", "edly", "eed", "ing", "ed"} {
+		if !strings.HasSuffix(word, suffix) {
+			continue
+		}
+
+		stem := word[:len(word)-len(suffix)]
+
+		if suffix == "eed" || suffix == "eedly" {
+			if len(stem) >= r1 {
+				return stem + "ee"
+			}
+
+			return word
+		}
+
+		if !hasEnglishVowel(stem) {
+			return word
+		}
+
+		switch {
+		case strings.HasSuffix(stem, "at"), strings.HasSuffix(stem, "bl"), strings.HasSuffix(stem, "iz"):
+			return stem + "e"
+		case endsWithEnglishDouble(stem):
+			return stem[:len(stem)-1]
+		case len(stem) <= r1 && endsWithShortSyllable(stem):
+			return stem + "e"
+		}
+
+		return stem
+	}
+
+	return word
+}
+
+// englishStep1c replaces the final y after a consonant with i
+func englishStep1c(word string) string {
+	n := len(word)
+
+	if n > 2 && (word[n-1] == 'y' || word[n-1] == 'Y') && !isEnglishVowel(word[n-2]) {
+		return word[:n-1] + "i"
+	}
+
+	return word
+}
+
+// englishStep2And3 applies the rule of the longest matched suffix in R1
+func englishStep2And3(word string, rules []suffixRule, r1, r2 int) string {
+	for _, rule := range rules {
+		if !strings.HasSuffix(word, rule.suffix) {
+			continue
+		}
+
+		stem := word[:len(word)-len(rule.suffix)]
+
+		if len(stem) < r1 {
+			return word
+		}
+
+		switch rule.suffix {
+		case "ogi":
+			if !strings.HasSuffix(stem, "l") {
+				return word
+			}
+		case "li":
+			if stem == "" || !strings.ContainsRune("cdeghkmnrt", rune(stem[len(stem)-1])) {
+				return word
+			}
+		case "ative":
+			if len(stem) < r2 {
+				return word
+			}
+		}
+
+		return stem + rule.replacement
+	}
+
+	return word
+}
+
+// englishStep4 removes the longest matched suffix in R2
+func englishStep4(word string, r2 int) string {
+	for _, suffix := range englishStep4Suffixes {
+		if !strings.HasSuffix(word, suffix) {
+			continue
+		}
+
+		stem := word[:len(word)-len(suffix)]
+
+		if len(stem) < r2 {
+			return word
+		}
+
+		if suffix == "ion" && !strings.HasSuffix(stem, "s") && !strings.HasSuffix(stem, "t") {
+			return word
+		}
+
+		return stem
+	}
+
+	return word
+}
+
+// englishStep5 removes the final e and the double l
+func englishStep5(word string, r1, r2 int) string {
+	n := len(word)
+
+	switch {
+	case hasSuffixAt(word, "e", r2):
+		return word[:n-1]
+	case hasSuffixAt(word, "e", r1) && !endsWithShortSyllable(word[:n-1]):
+		return word[:n-1]
+	case hasSuffixAt(word, "l", r2) && strings.HasSuffix(word, "ll"):
+		return word[:n-1]
+	}
+
+	return word
+}
+
+// englishRegion returns the position after the first non-vowel following a vowel starting from the given one
+func englishRegion(word string, start int) int {
+	for i := start + 1; i < len(word); i++ {
+		if !isEnglishVowel(word[i]) && isEnglishVowel(word[i-1]) {
+			return i + 1
+		}
+	}
+
+	return len(word)
+}
+
+// endsWithShortSyllable tells whether the word ends with a vowel between non-vowels (except w, x and Y)
+// or it is a vowel followed by a non-vowel
+func endsWithShortSyllable(word string) bool {
+	n := len(word)
+
+	if n == 2 {
+		return isEnglishVowel(word[0]) && !isEnglishVowel(word[1])
+	}
+
+	return n > 2 && !isEnglishVowel(word[n-3]) && isEnglishVowel(word[n-2]) &&
+		!isEnglishVowel(word[n-1]) && !strings.ContainsRune("wxY", rune(word[n-1]))
+}
+
+// endsWithEnglishDouble tells whether the word ends with a double consonant
+func endsWithEnglishDouble(word string) bool {
+	for _, double := range []string{"bb", "dd", "ff", "gg", "mm", "nn", "pp", "rr", "tt"} {
+		if strings.HasSuffix(word, double) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasEnglishVowel tells whether the text has a vowel
+func hasEnglishVowel(text string) bool {
+	return strings.ContainsAny(text, "aeiouy")
+}
+
+// isEnglishVowel tells whether the character is a vowel, the marked Y is a consonant
+func isEnglishVowel(c byte) bool {
+	return strings.IndexByte("aeiouy", c) >= 0
+}
+
+// isNotEnglish tells whether the rune can't be a part of a lowercased english word
+func isNotEnglish(r rune) bool {
+	return (r < 'a' || r > 'z') && r != '\''
+}
@@ -0,0 +1,148 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// KeyboardLayoutExpansion switches the keyboard layout of a query between English (QWERTY)
+	// and Russian (ЙЦУКЕН), e.g. "vfibyf" becomes "машина" and "ьфшт" becomes "main"
+	KeyboardLayoutExpansion = "keyboardLayout"
+	// TransliterationExpansion converts the latin letters of a query to the cyrillic ones
+	// by their sound, e.g. "mashina" becomes "машина"
+	TransliterationExpansion = "transliteration"
+)
+
+// QueryExpander generates the alternative spellings of a query
+type QueryExpander interface {
+	// Expand returns the alternative variants of the query, the query itself is not included
+	Expand(query string) []string
+}
+
+// latinKeys and cyrillicKeys are the characters of the same keys of English and Russian layouts
+const (
+	latinKeys    = "`qwertyuiop[]asdfghjkl;'zxcvbnm,./~QWERTYUIOP{}ASDFGHJKL:\"ZXCVBNM<>?"
+	cyrillicKeys = "ёйцукенгшщзхъфывапролджэячсмитьбю.ЁЙЦУКЕНГШЩЗХЪФЫВАПРОЛДЖЭЯЧСМИТЬБЮ,"
+)
+
+// transliterationTable is the phonetic latin to cyrillic mapping, the longer keys are preferred
+var transliterationTable = map[string]string{
+	"shch": "щ", "sch": "щ", "sh": "ш", "ch": "ч", "zh": "ж", "kh": "х", "ts": "ц",
+	"ya": "я", "ja": "я", "yu": "ю", "ju": "ю", "yo": "ё", "jo": "ё", "ye": "е",
+	"a": "а", "b": "б", "c": "ц", "d": "д", "e": "е", "f": "ф", "g": "г", "h": "х", "i": "и",
+	"j": "й", "k": "к", "l": "л", "m": "м", "n": "н", "o": "о", "p": "п", "q": "к", "r": "р",
+	"s": "с", "t": "т", "u": "у", "v": "в", "w": "в", "x": "кс", "y": "ы", "z": "з", "'": "ь",
+}
+
+// replaceExpander expands a query with the given replacer if the query has a character to replace
+type replaceExpander struct {
+	replacer *strings.Replacer
+	applies  func(r rune) bool
+	// lower tells to lowercase the query before the replacement
+	lower bool
+}
+
+// Expand returns the replaced query if it differs from the original one
+func (e *replaceExpander) Expand(query string) []string {
+	if strings.IndexFunc(query, e.applies) < 0 {
+		return nil
+	}
+
+	variant := query
+
+	if e.lower {
+		variant = strings.ToLower(variant)
+	}
+
+	variant = e.replacer.Replace(variant)
+
+	if variant == query {
+		return nil
+	}
+
+	return []string{variant}
+}
+
+// NewKeyboardLayoutExpander returns a QueryExpander that switches the layout of a query typed with
+// the wrong one active. Latin queries are converted to Russian layout and cyrillic ones to English
+func NewKeyboardLayoutExpander() QueryExpander {
+	toCyrillic := map[string]string{}
+	toLatin := map[string]string{}
+	cyrillic := []rune(cyrillicKeys)
+
+	for i, r := range []rune(latinKeys) {
+		toCyrillic[string(r)] = string(cyrillic[i])
+		toLatin[string(cyrillic[i])] = string(r)
+	}
+
+	return QueryExpanders{
+		&replaceExpander{
+			replacer: newReplacer(toCyrillic),
+			applies:  isLatin,
+		},
+		&replaceExpander{
+			replacer: newReplacer(toLatin),
+			applies:  isCyrillic,
+		},
+	}
+}
+
+// NewTransliterationExpander returns a QueryExpander that converts the latin letters of a query
+// to the cyrillic ones by their sound
+func NewTransliterationExpander() QueryExpander {
+	return &replaceExpander{
+		replacer: newReplacer(transliterationTable),
+		applies:  isLatin,
+		lower:    true,
+	}
+}
+
+// NewQueryExpander returns the expander of the given expansions
+func NewQueryExpander(expansions []string) (QueryExpander, error) {
+	expanders := QueryExpanders{}
+
+	for _, expansion := range expansions {
+		switch expansion {
+		case KeyboardLayoutExpansion:
+			expanders = append(expanders, NewKeyboardLayoutExpander())
+		case TransliterationExpansion:
+			expanders = append(expanders, NewTransliterationExpander())
+		default:
+			return nil, fmt.Errorf("unknown query expansion %s", expansion)
+		}
+	}
+
+	return expanders, nil
+}
+
+// QueryExpanders combines the variants of the expanders
+type QueryExpanders []QueryExpander
+
+// Expand returns the distinct variants of all the expanders
+func (e QueryExpanders) Expand(query string) []string {
+	variants := []string{}
+	seen := map[string]bool{query: true}
+
+	for _, expander := range e {
+		for _, variant := range expander.Expand(query) {
+			if !seen[variant] {
+				seen[variant] = true
+				variants = append(variants, variant)
+			}
+		}
+	}
+
+	return variants
+}
+
+// isLatin tells whether the rune is a latin letter
+func isLatin(r rune) bool {
+	return unicode.Is(unicode.Latin, r)
+}
+
+// isCyrillic tells whether the rune is a cyrillic letter
+func isCyrillic(r rune) bool {
+	return unicode.Is(unicode.Cyrillic, r)
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryExpander(t *testing.T) {
+	cases := []struct {
+		expansions []string
+		query      string
+		expected   []string
+	}{
+		{[]string{KeyboardLayoutExpansion}, "vfibyf", []string{"машина"}},
+		{[]string{KeyboardLayoutExpansion}, "ьфшт", []string{"main"}},
+		{[]string{KeyboardLayoutExpansion}, "123", []string{}},
+		{[]string{TransliterationExpansion}, "mashina", []string{"машина"}},
+		{[]string{TransliterationExpansion}, "Shchuka", []string{"щука"}},
+		{[]string{TransliterationExpansion}, "машина", []string{}},
+		{[]string{KeyboardLayoutExpansion, TransliterationExpansion}, "vfibyf", []string{"машина", "вфибыф"}},
+	}
+
+	for _, c := range cases {
+		expander, err := NewQueryExpander(c.expansions)
+
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+			continue
+		}
+
+		actual := expander.Expand(c.query)
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Test fail, expected %v, got %v", c.expected, actual)
+		}
+	}
+
+	if _, err := NewQueryExpander([]string{"unknown"}); err == nil {
+		t.Errorf("Test fail, expected error for an unknown expansion")
+	}
+}
@@ -0,0 +1,205 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizationStep is a step of the normalization pipeline
+type NormalizationStep string
+
+const (
+	// NFC composes the characters, e.g. "é" becomes "é"
+	NFC NormalizationStep = "nfc"
+	// NFKC composes the characters and replaces the compatibility ones, e.g. full-width "Ａ" becomes "A"
+	NFKC NormalizationStep = "nfkc"
+	// Lowercase maps the characters to their lower case
+	Lowercase NormalizationStep = "lowercase"
+	// CaseFold performs the unicode case folding, e.g. "ß" becomes "ss"
+	CaseFold NormalizationStep = "casefold"
+	// StripAccents removes the diacritical marks, e.g. "é" becomes "e" and "ё" becomes "е"
+	StripAccents NormalizationStep = "stripAccents"
+	// Map replaces the substrings of the mapping table
+	Map NormalizationStep = "map"
+)
+
+// Normalizer transforms a text before the tokenization, so the different spellings of a word
+// produce the same tokens
+type Normalizer interface {
+	// Normalize returns the normalized text
+	Normalize(text string) string
+}
+
+// NormalizationConfig describes the normalization pipeline
+type NormalizationConfig struct {
+	// Steps are applied in the given order
+	Steps []NormalizationStep `json:"steps"`
+	// Mapping is the table of the Map step, e.g. {"ё": "е"}
+	Mapping map[string]string `json:"mapping"`
+	// KeepAccents are the characters that are kept by the StripAccents step, e.g. "йЙ"
+	KeepAccents string `json:"keepAccents"`
+}
+
+// UnmarshalJSON reads and validates the normalization config
+func (c *NormalizationConfig) UnmarshalJSON(data []byte) error {
+	type config NormalizationConfig
+	value := config{}
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if _, err := NewNormalizer(NormalizationConfig(value)); err != nil {
+		return err
+	}
+
+	*c = NormalizationConfig(value)
+
+	return nil
+}
+
+// normalizer applies the steps of the pipeline one by one
+type normalizer struct {
+	steps []func(string) string
+}
+
+// NewNormalizer creates a Normalizer of the given pipeline
+func NewNormalizer(config NormalizationConfig) (Normalizer, error) {
+	n := &normalizer{}
+	hasMap := false
+
+	for _, step := range config.Steps {
+		switch step {
+		case NFC:
+			n.steps = append(n.steps, norm.NFC.String)
+		case NFKC:
+			n.steps = append(n.steps, norm.NFKC.String)
+		case Lowercase:
+			n.steps = append(n.steps, strings.ToLower)
+		case CaseFold:
+			n.steps = append(n.steps, foldCase)
+		case StripAccents:
+			n.steps = append(n.steps, newAccentsStripper(config.KeepAccents))
+		case Map:
+			if len(config.Mapping) == 0 {
+				return nil, fmt.Errorf("mapping table of the %s step is empty", Map)
+			}
+
+			if _, ok := config.Mapping[""]; ok {
+				return nil, fmt.Errorf("mapping table of the %s step has an empty key", Map)
+			}
+
+			n.steps = append(n.steps, newReplacer(config.Mapping).Replace)
+			hasMap = true
+		default:
+			return nil, fmt.Errorf("unknown normalization step %s", step)
+		}
+	}
+
+	if len(config.Mapping) > 0 && !hasMap {
+		return nil, fmt.Errorf("mapping table is declared without the %s step", Map)
+	}
+
+	return n, nil
+}
+
+// NewIdentityNormalizer returns a Normalizer that keeps the text as is
+func NewIdentityNormalizer() Normalizer {
+	return &normalizer{}
+}
+
+// Normalize returns the normalized text
+func (n *normalizer) Normalize(text string) string {
+	for _, step := range n.steps {
+		text = step(text)
+	}
+
+	return text
+}
+
+// foldCase performs the unicode case folding. A caser keeps a state, so it is created for each call
+func foldCase(text string) string {
+	return cases.Fold().String(text)
+}
+
+// newAccentsStripper returns a function that removes the nonspacing marks of the decomposed characters
+// except for the given ones
+func newAccentsStripper(keep string) func(string) string {
+	kept := map[rune]bool{}
+
+	for _, r := range norm.NFC.String(keep) {
+		kept[r] = true
+	}
+
+	return func(text string) string {
+		text = norm.NFC.String(text)
+		b := strings.Builder{}
+		b.Grow(len(text))
+
+		for _, r := range text {
+			if r < utf8.RuneSelf || kept[r] {
+				b.WriteRune(r)
+				continue
+			}
+
+			for _, d := range norm.NFD.String(string(r)) {
+				if !unicode.Is(unicode.Mn, d) {
+					b.WriteRune(d)
+				}
+			}
+		}
+
+		return b.String()
+	}
+}
+
+// newReplacer creates a replacer of the mapping table, the longer keys are preferred
+func newReplacer(mapping map[string]string) *strings.Replacer {
+	keys := make([]string, 0, len(mapping))
+
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	pairs := make([]string, 0, 2*len(keys))
+
+	for _, key := range keys {
+		pairs = append(pairs, key, mapping[key])
+	}
+
+	return strings.NewReplacer(pairs...)
+}
+
+// normalizeTokenizer normalizes the text before the tokenization
+type normalizeTokenizer struct {
+	tokenizer  Tokenizer
+	normalizer Normalizer
+}
+
+// NewNormalizeTokenizer returns a tokenizer that normalizes the text before the tokenization
+func NewNormalizeTokenizer(tokenizer Tokenizer, normalizer Normalizer) Tokenizer {
+	return &normalizeTokenizer{
+		tokenizer:  tokenizer,
+		normalizer: normalizer,
+	}
+}
+
+// Tokenize splits the given text on a sequence of tokens
+func (t *normalizeTokenizer) Tokenize(text string) []Token {
+	return t.tokenizer.Tokenize(t.normalizer.Normalize(text))
+}
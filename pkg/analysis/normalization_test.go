@@ -0,0 +1,90 @@
+package analysis
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizer(t *testing.T) {
+	cases := []struct {
+		config   NormalizationConfig
+		text     string
+		expected string
+	}{
+		{NormalizationConfig{}, "Caf\u00e9", "Caf\u00e9"},
+		{NormalizationConfig{Steps: []NormalizationStep{NFKC}}, "Ｔｏｙｏｔａ １２", "Toyota 12"},
+		{NormalizationConfig{Steps: []NormalizationStep{NFC}}, "cafe\u0301", "caf\u00e9"},
+		{NormalizationConfig{Steps: []NormalizationStep{Lowercase}}, "NISSAN Ёлка", "nissan ёлка"},
+		{NormalizationConfig{Steps: []NormalizationStep{CaseFold}}, "STRASSE Straße", "strasse strasse"},
+		{NormalizationConfig{Steps: []NormalizationStep{StripAccents}}, "caf\u00e9 naïve cafe\u0301 ёлка", "cafe naive cafe елка"},
+		{NormalizationConfig{Steps: []NormalizationStep{StripAccents}, KeepAccents: "йЙ"}, "йогурт ёж", "йогурт еж"},
+		{
+			NormalizationConfig{Steps: []NormalizationStep{Map}, Mapping: map[string]string{"ё": "е", "ph": "f", "p": "b"}},
+			"ёж photo pop",
+			"еж foto bob",
+		},
+		{
+			NormalizationConfig{Steps: []NormalizationStep{NFKC, CaseFold, StripAccents}},
+			"ＣＡＦÉ",
+			"cafe",
+		},
+	}
+
+	for _, c := range cases {
+		normalizer, err := NewNormalizer(c.config)
+
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+			continue
+		}
+
+		actual := normalizer.Normalize(c.text)
+
+		if actual != c.expected {
+			t.Errorf("Test fail, expected %v, got %v", c.expected, actual)
+		}
+	}
+}
+
+func TestNormalizationConfigValidation(t *testing.T) {
+	cases := []struct {
+		data string
+		fail bool
+	}{
+		{`{"steps": ["nfkc", "casefold", "stripAccents"]}`, false},
+		{`{"steps": ["map"], "mapping": {"ё": "е"}}`, false},
+		{`{"steps": ["unknown"]}`, true},
+		{`{"steps": ["map"]}`, true},
+		{`{"steps": ["map"], "mapping": {"": "е"}}`, true},
+		{`{"steps": ["nfc"], "mapping": {"ё": "е"}}`, true},
+	}
+
+	for _, c := range cases {
+		config := NormalizationConfig{}
+		err := json.Unmarshal([]byte(c.data), &config)
+
+		if (err != nil) != c.fail {
+			t.Errorf("Test fail, expected fail %v, got %v for %s", c.fail, err, c.data)
+		}
+	}
+}
+
+func TestNormalizeTokenizer(t *testing.T) {
+	normalizer, err := NewNormalizer(NormalizationConfig{Steps: []NormalizationStep{NFKC, StripAccents}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenizer := NewNormalizeTokenizer(NewNGramTokenizer(3), normalizer)
+	expected := []Token{"caf", "afe"}
+
+	for _, text := range []string{"cafe", "caf\u00e9", "cafe\u0301", "ｃａｆé"} {
+		actual := tokenizer.Tokenize(text)
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Test fail, expected %v, got %v", expected, actual)
+		}
+	}
+}
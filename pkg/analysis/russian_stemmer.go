@@ -0,0 +1,144 @@
+package analysis
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// russianStemmer implements the Snowball russian stemming algorithm
+// https://snowballstem.org/algorithms/russian/stemmer.html
+type russianStemmer struct{}
+
+// the endings of the first groups should be preceded by а or я, the longest matched ending is removed
+var (
+	russianPerfectiveGerund1 = []string{"в", "вши", "вшись"}
+	russianPerfectiveGerund2 = []string{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"}
+	russianReflexive         = []string{"ся", "сь"}
+	russianAdjective         = []string{
+		"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+	}
+	russianParticiple1 = []string{"ем", "нн", "вш", "ющ", "щ"}
+	russianParticiple2 = []string{"ивш", "ывш", "ующ"}
+	russianVerb1       = []string{
+		"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно",
+	}
+	russianVerb2 = []string{
+		"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым", "ен",
+		"ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
+	}
+	russianNoun = []string{
+		"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой", "ий", "й",
+		"иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия",
+		"ья", "я",
+	}
+	russianSuperlative  = []string{"ейш", "ейше"}
+	russianDerivational = []string{"ост", "ость"}
+)
+
+// NewRussianStemmer returns the Snowball russian stemmer
+func NewRussianStemmer() Stemmer {
+	return &russianStemmer{}
+}
+
+// Stem returns the stem of the lowercased word
+func (s *russianStemmer) Stem(word string) string {
+	word = strings.Replace(word, "ё", "е", -1)
+	rv := len(word)
+
+	for i, r := range word {
+		if isRussianVowel(r) {
+			rv = i + utf8.RuneLen(r)
+			break
+		}
+	}
+
+	r2 := russianRegion(word, russianRegion(word, 0))
+
+	// step 1: the inflectional endings
+	if n := russianEnding(word, rv, russianPerfectiveGerund1, russianPerfectiveGerund2); n > 0 {
+		word = word[:len(word)-n]
+	} else {
+		word = word[:len(word)-russianEnding(word, rv, nil, russianReflexive)]
+
+		if n := russianEnding(word, rv, nil, russianAdjective); n > 0 {
+			word = word[:len(word)-n]
+			word = word[:len(word)-russianEnding(word, rv, russianParticiple1, russianParticiple2)]
+		} else if n := russianEnding(word, rv, russianVerb1, russianVerb2); n > 0 {
+			word = word[:len(word)-n]
+		} else {
+			word = word[:len(word)-russianEnding(word, rv, nil, russianNoun)]
+		}
+	}
+
+	// step 2
+	if hasSuffixAt(word, "и", rv) {
+		word = strings.TrimSuffix(word, "и")
+	}
+
+	// step 3: the derivational endings
+	word = word[:len(word)-russianEnding(word, r2, nil, russianDerivational)]
+
+	// step 4: the superlative ending, the double н and the soft sign
+	if n := russianEnding(word, rv, nil, russianSuperlative); n > 0 {
+		word = word[:len(word)-n]
+	}
+
+	if hasSuffixAt(word, "нн", rv) {
+		word = strings.TrimSuffix(word, "н")
+	} else if hasSuffixAt(word, "ь", rv) {
+		word = strings.TrimSuffix(word, "ь")
+	}
+
+	return word
+}
+
+// russianEnding returns the byte length of the longest ending of the groups in the region, 0 if there is no ending.
+// An ending of the first group is matched only after а or я
+func russianEnding(word string, region int, group1, group2 []string) int {
+	longest, isGroup1 := "", false
+
+	for _, ending := range group1 {
+		if len(ending) > len(longest) && hasSuffixAt(word, ending, region) {
+			longest, isGroup1 = ending, true
+		}
+	}
+
+	for _, ending := range group2 {
+		if len(ending) > len(longest) && hasSuffixAt(word, ending, region) {
+			longest, isGroup1 = ending, false
+		}
+	}
+
+	if isGroup1 {
+		stem := word[:len(word)-len(longest)]
+
+		if !hasSuffixAt(stem, "а", region) && !hasSuffixAt(stem, "я", region) {
+			return 0
+		}
+	}
+
+	return len(longest)
+}
+
+// russianRegion returns the position after the first non-vowel following a vowel starting from the given one
+func russianRegion(word string, start int) int {
+	prevVowel := false
+
+	for i, r := range word[start:] {
+		vowel := isRussianVowel(r)
+
+		if prevVowel && !vowel {
+			return start + i + utf8.RuneLen(r)
+		}
+
+		prevVowel = vowel
+	}
+
+	return len(word)
+}
+
+// isRussianVowel tells whether the rune is a russian vowel
+func isRussianVowel(r rune) bool {
+	return strings.ContainsRune("аеиоуыэюя", r)
+}
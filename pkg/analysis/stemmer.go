@@ -0,0 +1,38 @@
+package analysis
+
+import "fmt"
+
+const (
+	// English is the language of the Porter2 stemmer
+	English = "english"
+	// Russian is the language of the Snowball russian stemmer
+	Russian = "russian"
+)
+
+// Stemmer reduces the inflected forms of a word to the same stem, e.g. "машинами" becomes "машин"
+type Stemmer interface {
+	// Stem returns the stem of the lowercased word
+	Stem(word string) string
+}
+
+// NewStemmer returns the stemmer of the given language
+func NewStemmer(language string) (Stemmer, error) {
+	switch language {
+	case English:
+		return NewEnglishStemmer(), nil
+	case Russian:
+		return NewRussianStemmer(), nil
+	default:
+		return nil, fmt.Errorf("there is no stemmer for the language %s", language)
+	}
+}
+
+// NewStemFilter returns a filter that replaces the tokens with their stems
+func NewStemFilter(stemmer Stemmer) TokenFilter {
+	return funcFilter(stemmer.Stem)
+}
+
+// hasSuffixAt tells whether the word has the suffix that starts not before the region
+func hasSuffixAt(word, suffix string, region int) bool {
+	return len(word) >= len(suffix) && len(word)-len(suffix) >= region && word[len(word)-len(suffix):] == suffix
+}
@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnglishStemmer(t *testing.T) {
+	cases := []struct {
+		word     string
+		expected string
+	}{
+		{"consign", "consign"},
+		{"consigned", "consign"},
+		{"consignment", "consign"},
+		{"consistently", "consist"},
+		{"consolatory", "consolatori"},
+		{"consolidated", "consolid"},
+		{"consolingly", "consol"},
+		{"conspiracy", "conspiraci"},
+		{"conspirators", "conspir"},
+		{"generously", "generous"},
+		{"happiness", "happi"},
+		{"running", "run"},
+		{"hoped", "hope"},
+		{"agreed", "agre"},
+		{"caresses", "caress"},
+		{"ponies", "poni"},
+		{"ties", "tie"},
+		{"cries", "cri"},
+		{"gas", "gas"},
+		{"gaps", "gap"},
+		{"skies", "sky"},
+		{"dying", "die"},
+		{"succeeding", "succeed"},
+		{"yelling", "yell"},
+		{"car's", "car"},
+		{"be", "be"},
+		{"машина", "машина"},
+	}
+
+	stemmer := NewEnglishStemmer()
+
+	for _, c := range cases {
+		actual := stemmer.Stem(c.word)
+
+		if actual != c.expected {
+			t.Errorf("Test fail, expected %v, got %v for %v", c.expected, actual, c.word)
+		}
+	}
+}
+
+func TestRussianStemmer(t *testing.T) {
+	cases := []struct {
+		word     string
+		expected string
+	}{
+		{"вагон", "вагон"},
+		{"вагонами", "вагон"},
+		{"вагонов", "вагон"},
+		{"важная", "важн"},
+		{"важнейшие", "важн"},
+		{"важничал", "важнича"},
+		{"важности", "важност"},
+		{"важностью", "важност"},
+		{"машинами", "машин"},
+		{"машины", "машин"},
+		{"красивые", "красив"},
+		{"улыбнувшись", "улыбнувш"},
+		{"одеваться", "одева"},
+		{"ёлки", "елк"},
+		{"car", "car"},
+	}
+
+	stemmer := NewRussianStemmer()
+
+	for _, c := range cases {
+		actual := stemmer.Stem(c.word)
+
+		if actual != c.expected {
+			t.Errorf("Test fail, expected %v, got %v for %v", c.expected, actual, c.word)
+		}
+	}
+}
+
+func TestStemFilter(t *testing.T) {
+	if _, err := NewStemmer("latin"); err == nil {
+		t.Errorf("Test fail, expected error for an unknown language")
+	}
+
+	stemmer, err := NewStemmer(Russian)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Token{"машин", "машин"}
+	actual := NewStemFilter(stemmer).Filter([]Token{"машинами", "машины"})
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Test fail, expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,174 @@
+package analysis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Synonyms maps a lowercased phrase to its alternative forms
+type Synonyms map[string][]string
+
+// ReadSynonyms reads the synonyms file. Each line is either a group of equivalent phrases separated
+// by commas, e.g. "chevy, chevrolet", or a one-way mapping, e.g. "vw => volkswagen, volkswagon".
+// Empty lines and lines starting with # are skipped
+func ReadSynonyms(path string) (Synonyms, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open synonyms file: %v", err)
+	}
+
+	defer file.Close()
+
+	return ParseSynonyms(file)
+}
+
+// ParseSynonyms parses the synonyms in the format of ReadSynonyms
+func ParseSynonyms(reader io.Reader) (Synonyms, error) {
+	synonyms := Synonyms{}
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if parts := strings.Split(line, "=>"); len(parts) > 1 {
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("line %d has several => separators", lineNumber)
+			}
+
+			sources, targets := splitPhrases(parts[0]), splitPhrases(parts[1])
+
+			if len(sources) == 0 || len(targets) == 0 {
+				return nil, fmt.Errorf("line %d has an empty side of the mapping", lineNumber)
+			}
+
+			for _, source := range sources {
+				synonyms.add(source, targets)
+			}
+
+			continue
+		}
+
+		group := splitPhrases(line)
+
+		if len(group) < 2 {
+			return nil, fmt.Errorf("line %d should have at least two synonyms", lineNumber)
+		}
+
+		for _, phrase := range group {
+			synonyms.add(phrase, group)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read synonyms: %v", err)
+	}
+
+	return synonyms, nil
+}
+
+// add appends the alternatives of the phrase skipping the phrase itself and the known ones
+func (s Synonyms) add(phrase string, alternatives []string) {
+	for _, alternative := range alternatives {
+		if alternative == phrase {
+			continue
+		}
+
+		known := false
+
+		for _, existing := range s[phrase] {
+			if existing == alternative {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			s[phrase] = append(s[phrase], alternative)
+		}
+	}
+}
+
+// splitPhrases splits the comma separated list on the lowercased phrases with the normalized spaces
+func splitPhrases(list string) []string {
+	phrases := []string{}
+
+	for _, phrase := range strings.Split(list, ",") {
+		if phrase = strings.Join(strings.Fields(strings.ToLower(phrase)), " "); phrase != "" {
+			phrases = append(phrases, phrase)
+		}
+	}
+
+	return phrases
+}
+
+// synonymExpander replaces the phrases of a query with their synonyms
+type synonymExpander struct {
+	synonyms Synonyms
+	// maxWords is the number of words of the longest phrase
+	maxWords int
+}
+
+// NewSynonymExpander returns a QueryExpander that replaces the phrases of a query with their synonyms,
+// a variant is generated for each synonym of each phrase, the longer phrases are preferred
+func NewSynonymExpander(synonyms Synonyms) QueryExpander {
+	maxWords := 0
+
+	for phrase := range synonyms {
+		if n := len(strings.Fields(phrase)); n > maxWords {
+			maxWords = n
+		}
+	}
+
+	return &synonymExpander{
+		synonyms: synonyms,
+		maxWords: maxWords,
+	}
+}
+
+// Expand returns the variants of the query with the replaced phrases
+func (e *synonymExpander) Expand(query string) []string {
+	words := strings.Fields(strings.ToLower(query))
+	variants := []string{}
+
+	for i := 0; i < len(words); {
+		matched := 0
+
+		for n := e.maxWords; n > 0 && matched == 0; n-- {
+			if i+n > len(words) {
+				continue
+			}
+
+			alternatives, ok := e.synonyms[strings.Join(words[i:i+n], " ")]
+
+			if !ok {
+				continue
+			}
+
+			matched = n
+			prefix, suffix := words[:i], words[i+n:]
+
+			for _, alternative := range alternatives {
+				variant := append(append(append([]string{}, prefix...), alternative), suffix...)
+				variants = append(variants, strings.Join(variant, " "))
+			}
+		}
+
+		if matched == 0 {
+			matched = 1
+		}
+
+		i += matched
+	}
+
+	return variants
+}
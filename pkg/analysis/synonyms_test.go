@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSynonyms(t *testing.T) {
+	data := `
+# brands
+chevy => chevrolet
+VW, Volkswagen, volkswagon
+big   apple, new york city
+`
+	synonyms, err := ParseSynonyms(strings.NewReader(data))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Synonyms{
+		"chevy":         {"chevrolet"},
+		"vw":            {"volkswagen", "volkswagon"},
+		"volkswagen":    {"vw", "volkswagon"},
+		"volkswagon":    {"vw", "volkswagen"},
+		"big apple":     {"new york city"},
+		"new york city": {"big apple"},
+	}
+
+	if !reflect.DeepEqual(synonyms, expected) {
+		t.Errorf("Test fail, expected %v, got %v", expected, synonyms)
+	}
+
+	for _, data := range []string{"chevy", "a => b => c", "=> chevrolet", "chevy =>"} {
+		if _, err := ParseSynonyms(strings.NewReader(data)); err == nil {
+			t.Errorf("Test fail, expected error for %s", data)
+		}
+	}
+}
+
+func TestSynonymExpander(t *testing.T) {
+	expander := NewSynonymExpander(Synonyms{
+		"chevy":         {"chevrolet"},
+		"vw":            {"volkswagen"},
+		"new york":      {"nyc"},
+		"new york city": {"big apple"},
+	})
+
+	cases := []struct {
+		query    string
+		expected []string
+	}{
+		{"Chevy Aveo", []string{"chevrolet aveo"}},
+		{"vw chevy", []string{"volkswagen chevy", "vw chevrolet"}},
+		{"new york city tour", []string{"big apple tour"}},
+		{"new york", []string{"nyc"}},
+		{"nissan", []string{}},
+	}
+
+	for _, c := range cases {
+		actual := expander.Expand(c.query)
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Test fail, expected %v, got %v", c.expected, actual)
+		}
+	}
+}
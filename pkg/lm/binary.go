@@ -34,6 +34,46 @@ func StoreBinaryLMFromGoogleFormat(directory store.Directory, config *Config) er
 		return fmt.Errorf("couldn't read ngrams: %v", err)
 	}
 
+	return storeBinary(directory, config, model, table)
+}
+
+// PruneBinaryLM prunes the language model stored in the binary format with the given prune config
+// and replaces the binary with the pruned one
+func PruneBinaryLM(directory store.Directory, config *Config, pruneConfig PruneConfig) error {
+	model, table, err := readBinary(directory, config)
+
+	if err != nil {
+		return err
+	}
+
+	pruned, err := Prune(model, pruneConfig)
+
+	if err != nil {
+		return fmt.Errorf("failed to prune the language model: %v", err)
+	}
+
+	return storeBinary(directory, config, pruned, table)
+}
+
+// RetrieveLMFromBinary retrieves a language model from the binary format
+func RetrieveLMFromBinary(directory store.Directory, config *Config) (LanguageModel, error) {
+	dict, err := dictionary.OpenCDBDictionary(config.GetDictionaryPath())
+
+	if err != nil {
+		return nil, err
+	}
+
+	model, table, err := readBinary(directory, config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLanguageModel(model, NewIndexer(dict, table), config)
+}
+
+// storeBinary persists the given model and the mph table in the binary format
+func storeBinary(directory store.Directory, config *Config, model NGramModel, table mph.MPH) error {
 	out, err := directory.CreateOutput(config.GetBinaryPath())
 
 	if err != nil {
@@ -57,18 +97,12 @@ func StoreBinaryLMFromGoogleFormat(directory store.Directory, config *Config) er
 	return nil
 }
 
-// RetrieveLMFromBinary retrieves a language model from the binary format
-func RetrieveLMFromBinary(directory store.Directory, config *Config) (LanguageModel, error) {
-	dict, err := dictionary.OpenCDBDictionary(config.GetDictionaryPath())
-
-	if err != nil {
-		return nil, err
-	}
-
+// readBinary retrieves the model and the mph table from the binary format
+func readBinary(directory store.Directory, config *Config) (NGramModel, mph.MPH, error) {
 	in, err := directory.OpenInput(config.GetBinaryPath())
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to open the lm binary file: %v", err)
+		return nil, nil, fmt.Errorf("failed to open the lm binary file: %v", err)
 	}
 
 	var (
@@ -78,18 +112,18 @@ func RetrieveLMFromBinary(directory store.Directory, config *Config) (LanguageMo
 	)
 
 	if err := dec.Decode(&model); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if _, err := table.Load(in); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := in.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close a binary input: %v", err)
+		return nil, nil, fmt.Errorf("failed to close a binary input: %v", err)
 	}
 
-	return NewLanguageModel(model, NewIndexer(dict, table), config)
+	return model, table, nil
 }
 
 // buildDictionary builds a dictionary for the given config
@@ -3,24 +3,35 @@ package lm
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
+	"runtime"
 
 	"github.com/suggest-go/suggest/pkg/alphabet"
+	"github.com/suggest-go/suggest/pkg/analysis"
 )
 
 // Config represents a configuration of a language model
 type Config struct {
-	Name        string   `json:"name"`
-	NGramOrder  uint8    `json:"nGramOrder"`
-	SourcePath  string   `json:"source"`
-	OutputPath  string   `json:"output"`
-	Alphabet    []string `json:"alphabet"`
-	Separators  []string `json:"separators"`
-	StartSymbol string   `json:"startSymbol"`
-	EndSymbol   string   `json:"endSymbol"`
-	basePath    string
+	Name        string      `json:"name"`
+	NGramOrder  uint8       `json:"nGramOrder"`
+	SourcePath  string      `json:"source"`
+	Sources     []string    `json:"sources"`
+	Workers     int         `json:"workers"`
+	OutputPath  string      `json:"output"`
+	Alphabet    []string    `json:"alphabet"`
+	Separators  []string    `json:"separators"`
+	StartSymbol string      `json:"startSymbol"`
+	EndSymbol   string      `json:"endSymbol"`
+	Prune       PruneConfig `json:"prune"`
+	// Normalization is applied to the corpus and to the queries, only the text is lowercased by default
+	Normalization *analysis.NormalizationConfig `json:"normalization"`
+	// Stemmer is the language of the stemmer, the nGrams are counted over the stems of the words if it is set
+	Stemmer  string `json:"stemmer"`
+	basePath string
 }
 
 // GetWordsAlphabet returns a word alphabet corresponding to the declaration
@@ -33,6 +44,47 @@ func (c *Config) GetSeparatorsAlphabet() alphabet.Alphabet {
 	return alphabet.CreateAlphabet(c.Separators)
 }
 
+// GetNormalizer returns the normalizer of the corpus and the queries
+func (c *Config) GetNormalizer() (analysis.Normalizer, error) {
+	if c.Normalization == nil {
+		return analysis.NewIdentityNormalizer(), nil
+	}
+
+	return analysis.NewNormalizer(*c.Normalization)
+}
+
+// GetStemmer returns the stemmer of the words, nil if the words are not stemmed
+func (c *Config) GetStemmer() (analysis.Stemmer, error) {
+	if c.Stemmer == "" {
+		return nil, nil
+	}
+
+	return analysis.NewStemmer(c.Stemmer)
+}
+
+// GetTokenizer returns the tokenizer of the corpus and the queries
+func (c *Config) GetTokenizer() (analysis.Tokenizer, error) {
+	normalizer, err := c.GetNormalizer()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a normalizer: %v", err)
+	}
+
+	stemmer, err := c.GetStemmer()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a stemmer: %v", err)
+	}
+
+	tokenizer := NewTokenizer(c.GetWordsAlphabet())
+
+	if stemmer != nil {
+		tokenizer = analysis.NewFilterTokenizer(tokenizer, analysis.NewStemFilter(stemmer))
+	}
+
+	return analysis.NewNormalizeTokenizer(tokenizer, normalizer), nil
+}
+
 // GetDictionaryPath returns a stored path for the dictionary
 func (c *Config) GetDictionaryPath() string {
 	return fmt.Sprintf("%s/%s.cdb", c.GetOutputPath(), c.Name)
@@ -61,6 +113,73 @@ func (c *Config) GetSourcePath() string {
 	return c.SourcePath
 }
 
+// GetSourcePaths returns the list of corpus files of the language model.
+// Both the source and the sources declarations are treated as glob patterns
+func (c *Config) GetSourcePaths() ([]string, error) {
+	patterns := c.Sources
+
+	if c.SourcePath != "" {
+		patterns = append([]string{c.SourcePath}, patterns...)
+	}
+
+	paths := []string{}
+	seen := map[string]bool{}
+
+	for _, pattern := range patterns {
+		if !path.IsAbs(pattern) {
+			pattern = fmt.Sprintf("%s/%s", c.basePath, pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid source pattern %s: %v", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("there are no source files for the pattern %s", pattern)
+		}
+
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				paths = append(paths, match)
+			}
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("source files are not declared")
+	}
+
+	return paths, nil
+}
+
+// GetWorkers returns the number of workers that count nGrams in parallel
+func (c *Config) GetWorkers() int {
+	if c.Workers <= 0 {
+		return runtime.NumCPU()
+	}
+
+	return c.Workers
+}
+
+// GetRetrieverFactory returns a factory of the sentence retrievers of the source readers.
+// The tokenizer is created once and shared by all the retrievers
+func (c *Config) GetRetrieverFactory() (RetrieverFactory, error) {
+	tokenizer, err := c.GetTokenizer()
+
+	if err != nil {
+		return nil, err
+	}
+
+	separators := c.GetSeparatorsAlphabet()
+
+	return func(reader io.Reader) SentenceRetriever {
+		return NewSentenceRetriever(tokenizer, reader, separators)
+	}, nil
+}
+
 // ReadConfig reads a language model config from the given reader
 func ReadConfig(configPath string) (*Config, error) {
 	configFile, err := os.Open(configPath)
@@ -83,6 +202,18 @@ func ReadConfig(configPath string) (*Config, error) {
 		return nil, err
 	}
 
+	if _, err := config.GetStemmer(); err != nil {
+		return nil, err
+	}
+
+	if _, err := alphabet.ParseAlphabet(config.Alphabet); err != nil {
+		return nil, fmt.Errorf("invalid alphabet: %v", err)
+	}
+
+	if _, err := alphabet.ParseAlphabet(config.Separators); err != nil {
+		return nil, fmt.Errorf("invalid separators: %v", err)
+	}
+
 	config.basePath = path.Dir(configPath)
 
 	return &config, nil
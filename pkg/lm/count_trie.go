@@ -14,6 +14,8 @@ type CountTrie interface {
 	Put(sentence Sentence, count WordCount)
 	// Walk iterates through trie and calls walker function on each element.
 	Walk(walker TrieIterator) error
+	// Merge adds all counts of the other trie to the current one.
+	Merge(other CountTrie) error
 }
 
 // ErrInvalidIndex tells that there is not data for the provided index index
@@ -98,6 +100,15 @@ func (t *countTrie) Walk(walker TrieIterator) (err error) {
 	return err
 }
 
+// Merge adds all counts of the other trie to the current one.
+func (t *countTrie) Merge(other CountTrie) error {
+	return other.Walk(func(path Sentence, count WordCount) error {
+		t.Put(path, count)
+
+		return nil
+	})
+}
+
 // mapToUint32 maps the given token to a index value
 func (t *countTrie) mapToUint32(token Token) uint32 {
 	index, ok := t.table[token]
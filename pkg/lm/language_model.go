@@ -14,6 +14,12 @@ type LanguageModel interface {
 	GetWordID(token Token) (WordID, error)
 	// Next returns the list of candidates for the given sequence
 	Next(sequence []WordID) (ScorerNext, error)
+	// TopNext returns topK the most probable words that follow after the given sequence
+	TopNext(sequence []WordID, topK int) ([]NextCandidate, error)
+	// ScoreNext returns a lm weight of the word that follows after the given sequence
+	ScoreNext(sequence []WordID, word WordID) float64
+	// ScoreEnd returns a lm weight of the end of the sentence that follows after the given sequence
+	ScoreEnd(sequence []WordID) float64
 }
 
 // languageModel implements LanguageModel interface
@@ -102,20 +108,72 @@ func (lm *languageModel) Next(sequence []WordID) (ScorerNext, error) {
 
 	if len(sequence)+1 < nGramOrder {
 		sequence = lm.leftWrapSentence(sequence)
-	} else if len(sequence) > nGramOrder {
+	} else if len(sequence) >= nGramOrder {
 		sequence = sequence[len(sequence)-nGramOrder+1:]
-	} else if len(sequence) == nGramOrder {
-		sequence = sequence[:nGramOrder-1]
 	}
 
 	return lm.model.Next(sequence)
 }
 
+// TopNext returns topK the most probable words that follow after the given sequence.
+// The start and the end symbols are never returned as candidates
+func (lm *languageModel) TopNext(sequence []WordID, topK int) ([]NextCandidate, error) {
+	sequence = lm.nextContext(sequence)
+
+	// reserve the places for the start and the end symbols
+	candidates, err := lm.model.TopNext(sequence, topK+2)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]NextCandidate, 0, topK)
+
+	for _, candidate := range candidates {
+		if len(result) == topK {
+			break
+		}
+
+		if candidate.WordID == lm.startSymbol || candidate.WordID == lm.endSymbol {
+			continue
+		}
+
+		result = append(result, candidate)
+	}
+
+	return result, nil
+}
+
 // split splits the given sequence of WordIDs to nGrams
 func (lm *languageModel) split(sequence []WordID) NGrams {
 	return splitIntoNGrams(sequence, lm.config.NGramOrder)
 }
 
+// ScoreNext returns a lm weight of the word that follows after the given sequence
+func (lm *languageModel) ScoreNext(sequence []WordID, word WordID) float64 {
+	return lm.model.ScoreNext(lm.nextContext(sequence), word)
+}
+
+// ScoreEnd returns a lm weight of the end of the sentence that follows after the given sequence
+func (lm *languageModel) ScoreEnd(sequence []WordID) float64 {
+	return lm.ScoreNext(sequence, lm.endSymbol)
+}
+
+// nextContext returns the context of the model order for the word that follows after the given sequence
+func (lm *languageModel) nextContext(sequence []WordID) []WordID {
+	contextSize := int(lm.config.NGramOrder) - 1
+
+	if len(sequence) < contextSize {
+		sequence = lm.leftWrapSentence(sequence)
+	}
+
+	if len(sequence) > contextSize {
+		sequence = sequence[len(sequence)-contextSize:]
+	}
+
+	return sequence
+}
+
 // wrapSentence wraps the given sentence with start and end symbols
 func (lm *languageModel) wrapSentence(sentence []WordID) []WordID {
 	return lm.leftWrapSentence(lm.rightWrapSentence(sentence))
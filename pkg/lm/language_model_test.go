@@ -65,6 +65,213 @@ func TestScoreSentenceFromBinary(t *testing.T) {
 	testLM(lm, t)
 }
 
+func TestTopNext(t *testing.T) {
+	config, err := ReadConfig("testdata/config-example.json")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	directory, err := store.NewFSDirectory(config.GetOutputPath())
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	lm, err := RetrieveLMFromBinary(directory, config)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		sentence Sentence
+		expected []string
+		scores   []float64
+	}{
+		{Sentence{"sam", "i"}, []string{"am", "do"}, []float64{0, -2.0149}},
+		{Sentence{"i", "am"}, []string{"sam", "i"}, []float64{-0.6931, -3.7297}},
+		{Sentence{"am"}, []string{"sam", "i"}, []float64{-1.6094, -3.7297}},
+	}
+
+	for _, c := range cases {
+		ids, err := MapIntoListOfWordIDs(lm, c.sentence)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		candidates, err := lm.TopNext(ids, 2)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if len(candidates) != len(c.expected) {
+			t.Errorf("Test fail, for %v expected %v, got %v", c.sentence, c.expected, candidates)
+			continue
+		}
+
+		for i, candidate := range candidates {
+			id, _ := lm.GetWordID(c.expected[i])
+
+			if candidate.WordID != id || math.Abs(candidate.Score-c.scores[i]) >= tolerance {
+				t.Errorf("Test fail, for %v expected %v, got %v", c.sentence, c.expected, candidates)
+			}
+		}
+	}
+}
+
+func TestScoreNext(t *testing.T) {
+	config, err := ReadConfig("testdata/config-example.json")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	directory, err := store.NewFSDirectory(config.GetOutputPath())
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	lm, err := RetrieveLMFromBinary(directory, config)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		sentence Sentence
+		word     string
+		expected float64
+	}{
+		{Sentence{"sam", "i"}, "am", 0},
+		{Sentence{"sam", "i"}, "do", -2.0149},
+		{Sentence{"i", "am"}, "sam", -0.6931},
+		{Sentence{"i", "am"}, "i", -3.7297},
+		{Sentence{"am"}, "sam", -1.6094},
+		{Sentence{"green", "eggs", "and", "ham", "i"}, "do", -2.0149},
+		{Sentence{}, "i", -0.4055},
+		{Sentence{"i", "am"}, "unknown", UnknownWordScore},
+	}
+
+	for _, c := range cases {
+		ids, err := MapIntoListOfWordIDs(lm, c.sentence)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		word, err := lm.GetWordID(c.word)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if actual := lm.ScoreNext(ids, word); math.Abs(actual-c.expected) >= tolerance {
+			t.Errorf("Test fail, for %v %s expected %v, got %v", c.sentence, c.word, c.expected, actual)
+		}
+	}
+}
+
+func TestScoreEnd(t *testing.T) {
+	config, err := ReadConfig("testdata/config-example.json")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	directory, err := store.NewFSDirectory(config.GetOutputPath())
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lm, err := RetrieveLMFromBinary(directory, config)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	endSymbol, err := lm.GetWordID(config.EndSymbol)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, sentence := range []Sentence{{}, {"i", "am", "sam"}, {"i", "do", "not", "like", "green", "eggs", "and", "ham"}} {
+		ids, err := MapIntoListOfWordIDs(lm, sentence)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		expected := lm.ScoreNext(ids, endSymbol)
+
+		if actual := lm.ScoreEnd(ids); actual != expected || actual <= UnknownWordScore {
+			t.Errorf("Test fail, for %v expected %v, got %v", sentence, expected, actual)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	config, err := ReadConfig("testdata/config-example.json")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	directory, err := store.NewFSDirectory(config.GetOutputPath())
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	lm, err := RetrieveLMFromBinary(directory, config)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// the context of the next word is the last nGramOrder - 1 words of the sequence
+	cases := []struct {
+		sentence Sentence
+		word     string
+		expected float64
+	}{
+		{Sentence{"i", "am"}, "sam", -0.6931},
+		{Sentence{"sam", "i", "am"}, "sam", -0.6931},
+		{Sentence{"sam", "i", "am"}, "am", UnknownWordScore},
+		{Sentence{"am", "sam", "i", "am"}, "sam", -0.6931},
+	}
+
+	for _, c := range cases {
+		ids, err := MapIntoListOfWordIDs(lm, c.sentence)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		word, err := lm.GetWordID(c.word)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		scorer, err := lm.Next(ids)
+
+		if err != nil || scorer == nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+
+		if actual := scorer.ScoreNext(word); math.Abs(actual-c.expected) >= tolerance {
+			t.Errorf("Test fail, for %v %s expected %v, got %v", c.sentence, c.word, c.expected, actual)
+		}
+	}
+}
+
 func testLM(lm LanguageModel, t *testing.T) {
 	cases := []struct {
 		sentence      Sentence
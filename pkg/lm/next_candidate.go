@@ -0,0 +1,91 @@
+package lm
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// NextCandidate is a word that follows after a sequence of words
+type NextCandidate struct {
+	// WordID is an index of the word
+	WordID WordID
+	// Score is a lm score of the word built on the sequence
+	Score float64
+}
+
+// less tells whether the given candidate is less probable than the other
+func (c NextCandidate) less(o NextCandidate) bool {
+	if c.Score == o.Score {
+		return c.WordID > o.WordID
+	}
+
+	return c.Score < o.Score
+}
+
+// nextCandidateHeap implements heap.Interface
+type nextCandidateHeap []NextCandidate
+
+func (h nextCandidateHeap) Len() int            { return len(h) }
+func (h nextCandidateHeap) Less(i, j int) bool  { return h[i].less(h[j]) }
+func (h nextCandidateHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *nextCandidateHeap) Push(x interface{}) { *h = append(*h, x.(NextCandidate)) }
+func (h *nextCandidateHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return x
+}
+
+// nextCandidateQueue selects topK the most probable candidates
+type nextCandidateQueue struct {
+	topK int
+	h    nextCandidateHeap
+}
+
+// newNextCandidateQueue creates a new instance of nextCandidateQueue
+func newNextCandidateQueue(topK int) *nextCandidateQueue {
+	return &nextCandidateQueue{
+		topK: topK,
+		h:    make(nextCandidateHeap, 0, topK),
+	}
+}
+
+// add adds the candidate to the queue if it belongs to topK candidates
+func (q *nextCandidateQueue) add(candidate NextCandidate) {
+	if q.topK <= 0 {
+		return
+	}
+
+	if !q.isFull() {
+		heap.Push(&q.h, candidate)
+		return
+	}
+
+	if q.h[0].less(candidate) {
+		q.h[0] = candidate
+		heap.Fix(&q.h, 0)
+	}
+}
+
+// isFull tells whether the queue has collected topK candidates
+func (q *nextCandidateQueue) isFull() bool {
+	return q.topK > 0 && len(q.h) == q.topK
+}
+
+// lowestScore returns the lowest score of the collected candidates
+func (q *nextCandidateQueue) lowestScore() float64 {
+	return q.h[0].Score
+}
+
+// candidates returns the collected candidates sorted by score in descending order
+func (q *nextCandidateQueue) candidates() []NextCandidate {
+	result := make([]NextCandidate, len(q.h))
+	copy(result, q.h)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[j].less(result[i])
+	})
+
+	return result
+}
@@ -1,10 +1,19 @@
 package lm
 
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
 // NGramBuilder is an entity that responsible for creating CountTrie
 type NGramBuilder struct {
 	startSymbol, endSymbol Token
 }
 
+// RetrieverFactory creates a SentenceRetriever for the given source reader
+type RetrieverFactory func(reader io.Reader) SentenceRetriever
+
 // NewNGramBuilder returns new instance of NGramBuilder
 func NewNGramBuilder(
 	startSymbol, endSymbol string,
@@ -16,8 +25,106 @@ func NewNGramBuilder(
 }
 
 // Build builds CountTrie with nGrams
-func (nb *NGramBuilder) Build(retriever SentenceRetriever, nGramOrder uint8) CountTrie {
+func (nb *NGramBuilder) Build(retriever SentenceRetriever, nGramOrder uint8) (CountTrie, error) {
 	trie := NewCountTrie()
+
+	if err := nb.count(trie, retriever, nGramOrder); err != nil {
+		return nil, err
+	}
+
+	return trie, nil
+}
+
+// BuildFromSources builds CountTrie with nGrams for the given list of source files.
+// The sources are processed by the given number of workers, each of them
+// counts nGrams in its own trie. The tries are merged into one at the end.
+func (nb *NGramBuilder) BuildFromSources(
+	sources []string,
+	factory RetrieverFactory,
+	nGramOrder uint8,
+	workers int,
+) (CountTrie, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	if workers > len(sources) {
+		workers = len(sources)
+	}
+
+	var (
+		pathCh = make(chan string, len(sources))
+		errCh  = make(chan error, workers)
+		tries  = make([]CountTrie, workers)
+		wg     = sync.WaitGroup{}
+	)
+
+	for _, path := range sources {
+		pathCh <- path
+	}
+
+	close(pathCh)
+
+	for i := range tries {
+		tries[i] = NewCountTrie()
+		wg.Add(1)
+
+		go func(trie CountTrie) {
+			defer wg.Done()
+
+			for path := range pathCh {
+				if err := nb.countSource(trie, path, factory, nGramOrder); err != nil {
+					errCh <- err
+					return
+				}
+			}
+		}(tries[i])
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+
+	if len(tries) == 0 {
+		return NewCountTrie(), nil
+	}
+
+	trie := tries[0]
+
+	for _, other := range tries[1:] {
+		if err := trie.Merge(other); err != nil {
+			return nil, fmt.Errorf("failed to merge count tries: %v", err)
+		}
+	}
+
+	return trie, nil
+}
+
+// countSource counts nGrams of the given source file into the trie
+func (nb *NGramBuilder) countSource(trie CountTrie, path string, factory RetrieverFactory, nGramOrder uint8) error {
+	source, err := OpenSource(path)
+
+	if err != nil {
+		return err
+	}
+
+	if err := nb.count(trie, factory(source), nGramOrder); err != nil {
+		source.Close()
+		return fmt.Errorf("failed to read a source file %s: %v", path, err)
+	}
+
+	if err := source.Close(); err != nil {
+		return fmt.Errorf("failed to close a source file %s: %v", path, err)
+	}
+
+	return nil
+}
+
+// count puts all nGrams of the retrieved sentences into the given trie
+func (nb *NGramBuilder) count(trie CountTrie, retriever SentenceRetriever, nGramOrder uint8) error {
 	ch, quit := nb.produce(retriever)
 
 	for {
@@ -32,16 +139,16 @@ func (nb *NGramBuilder) Build(retriever SentenceRetriever, nGramOrder uint8) Cou
 				}
 			}
 
-		case <-quit:
-			return trie
+		case err := <-quit:
+			return err
 		}
 	}
 }
 
-// produce transfers retrieved sentences to the channel
-func (nb *NGramBuilder) produce(retriever SentenceRetriever) (chan Sentence, chan bool) {
+// produce transfers retrieved sentences to the channel, the retriever error is sent to quit at the end
+func (nb *NGramBuilder) produce(retriever SentenceRetriever) (chan Sentence, chan error) {
 	ch := make(chan Sentence)
-	quit := make(chan bool)
+	quit := make(chan error)
 
 	go func() {
 		for {
@@ -57,7 +164,7 @@ func (nb *NGramBuilder) produce(retriever SentenceRetriever) (chan Sentence, cha
 			ch <- sentence
 		}
 
-		quit <- true
+		quit <- retriever.Err()
 	}()
 
 	return ch, quit
@@ -0,0 +1,182 @@
+package lm
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/alphabet"
+)
+
+func TestBuildFromSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ngram-builder")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	texts := []string{
+		"I am Sam\nSam I am\n",
+		"I do not like green eggs and ham\n",
+		"Sam I am\n",
+	}
+
+	sources := make([]string, 0, len(texts))
+
+	for i, text := range texts {
+		path := filepath.Join(dir, string(rune('a'+i))+".txt")
+
+		if i%2 == 1 {
+			path += ".gz"
+		}
+
+		if err := writeSource(path, text, i%2 == 1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		sources = append(sources, path)
+	}
+
+	var (
+		builder = NewNGramBuilder("<S>", "</S>")
+		words   = alphabet.NewCompositeAlphabet([]alphabet.Alphabet{alphabet.NewEnglishAlphabet()})
+		stop    = alphabet.NewSimpleAlphabet([]rune{'\n'})
+		factory = func(reader io.Reader) SentenceRetriever {
+			return NewSentenceRetriever(NewTokenizer(words), reader, stop)
+		}
+	)
+
+	counts, err := builder.Build(factory(strings.NewReader(strings.Join(texts, ""))), 3)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := collectCounts(t, counts)
+
+	for _, workers := range []int{1, 2, 8} {
+		trie, err := builder.BuildFromSources(sources, factory, 3, workers)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		actual := collectCounts(t, trie)
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Test fail, workers %d, expected %v, got %v", workers, expected, actual)
+		}
+	}
+}
+
+func TestBuildReadError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ngram-builder")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	valid, truncated := filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt.gz")
+
+	if err := writeSource(valid, "I am Sam\n", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := writeSource(truncated, strings.Repeat("Sam I am\n", 100), true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(truncated)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := ioutil.WriteFile(truncated, data[:len(data)/2], 0600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var (
+		builder = NewNGramBuilder("<S>", "</S>")
+		words   = alphabet.NewCompositeAlphabet([]alphabet.Alphabet{alphabet.NewEnglishAlphabet()})
+		stop    = alphabet.NewSimpleAlphabet([]rune{'\n'})
+		factory = func(reader io.Reader) SentenceRetriever {
+			return NewSentenceRetriever(NewTokenizer(words), reader, stop)
+		}
+	)
+
+	if _, err := builder.Build(factory(errReader{}), 3); err != errRead {
+		t.Errorf("Test fail, expected %v, got %v", errRead, err)
+	}
+
+	for _, workers := range []int{1, 2} {
+		if _, err := builder.BuildFromSources([]string{valid, truncated}, factory, 3, workers); err == nil {
+			t.Errorf("Test fail, workers %d, expected error of the truncated source", workers)
+		}
+	}
+}
+
+// errRead is the error of errReader
+var errRead = errors.New("read error")
+
+// errReader is a reader that always fails
+type errReader struct{}
+
+// Read implements io.Reader interface
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errRead
+}
+
+// writeSource writes the given text to the path with optional gzip compression
+func writeSource(path, text string, compress bool) error {
+	file, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	var writer io.WriteCloser = file
+
+	if compress {
+		writer = gzip.NewWriter(file)
+	}
+
+	if _, err := writer.Write([]byte(text)); err != nil {
+		return err
+	}
+
+	if compress {
+		if err := writer.Close(); err != nil {
+			return err
+		}
+	}
+
+	return file.Close()
+}
+
+// collectCounts returns all paths of the given trie with their counts
+func collectCounts(t *testing.T, trie CountTrie) map[string]WordCount {
+	counts := map[string]WordCount{}
+
+	err := trie.Walk(func(path Sentence, count WordCount) error {
+		counts[strings.Join(path, " ")] += count
+
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	return counts
+}
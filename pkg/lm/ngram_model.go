@@ -14,6 +14,10 @@ type NGramModel interface {
 	Score(nGrams []WordID) float64
 	// Next returns a list of WordID which follow after the given sequence of nGrams
 	Next(nGrams []WordID) (ScorerNext, error)
+	// TopNext returns topK the most probable words which follow after the given sequence of nGrams
+	TopNext(nGrams []WordID, topK int) ([]NextCandidate, error)
+	// ScoreNext returns a lm value of the word which follows after the given sequence of nGrams
+	ScoreNext(nGrams []WordID, word WordID) float64
 }
 
 const (
@@ -95,6 +99,92 @@ func (m *nGramModel) Next(nGrams []WordID) (ScorerNext, error) {
 	}, nil
 }
 
+// TopNext returns topK the most probable words which follow after the given sequence of nGrams.
+// Words that have never followed the whole sequence are scored with the backoff to its shorter suffixes
+func (m *nGramModel) TopNext(nGrams []WordID, topK int) ([]NextCandidate, error) {
+	if int(m.nGramOrder) <= len(nGrams) {
+		return nil, errors.New("nGrams length should be less than the nGramModel order")
+	}
+
+	var (
+		queue  = newNextCandidateQueue(topK)
+		seen   = make(map[WordID]struct{})
+		factor = float64(1)
+	)
+
+	for start := 0; start <= len(nGrams); start, factor = start+1, factor*alpha {
+		// candidates of shorter contexts can't be scored higher than the factor
+		if queue.isFull() && queue.lowestScore() >= math.Log(factor) {
+			break
+		}
+
+		context := nGrams[start:]
+		contextCount, parent := m.contextCount(context)
+
+		if contextCount == 0 {
+			continue
+		}
+
+		subVector := m.indices[len(context)].SubVector(parent)
+
+		if subVector == nil {
+			continue
+		}
+
+		subVector.Iterate(func(word WordID, count WordCount) {
+			if _, ok := seen[word]; ok {
+				return
+			}
+
+			seen[word] = struct{}{}
+			queue.add(NextCandidate{
+				WordID: word,
+				Score:  math.Log(factor * float64(count) / float64(contextCount)),
+			})
+		})
+	}
+
+	return queue.candidates(), nil
+}
+
+// ScoreNext returns a lm value of the word which follows after the given sequence of nGrams.
+// If the word has never followed the whole sequence, the score backs off to its shorter suffixes
+func (m *nGramModel) ScoreNext(nGrams []WordID, word WordID) float64 {
+	if len(nGrams) >= int(m.nGramOrder) {
+		nGrams = nGrams[len(nGrams)-int(m.nGramOrder)+1:]
+	}
+
+	factor := float64(1)
+
+	for start := 0; start <= len(nGrams); start, factor = start+1, factor*alpha {
+		context := nGrams[start:]
+		contextCount, parent := m.contextCount(context)
+
+		if contextCount == 0 {
+			continue
+		}
+
+		if count, _ := m.indices[len(context)].GetCount(word, parent); count > 0 {
+			return math.Log(factor * float64(count) / float64(contextCount))
+		}
+	}
+
+	return UnknownWordScore
+}
+
+// contextCount returns the count and the context offset of the given sequence of nGrams
+func (m *nGramModel) contextCount(nGrams []WordID) (WordCount, ContextOffset) {
+	count, parent := m.indices[0].CorpusCount(), InvalidContextOffset
+
+	for i, nGram := range nGrams {
+		if count, parent = m.indices[i].GetCount(nGram, parent); count == 0 {
+			return 0, InvalidContextOffset
+		}
+	}
+
+	return count, parent
+}
+
 // MarshalBinary encodes the receiver into a binary form and returns the result.
 func (m *nGramModel) MarshalBinary() ([]byte, error) {
 	buf := bytes.Buffer{}
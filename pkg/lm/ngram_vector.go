@@ -30,6 +30,8 @@ type NGramVector interface {
 	CorpusCount() WordCount
 	// SubVector returns NGramVector for the given context
 	SubVector(context ContextOffset) NGramVector
+	// Iterate calls the iterator for each pair (word, count) of the vector
+	Iterate(iterator func(word WordID, count WordCount))
 }
 
 const (
@@ -94,6 +96,13 @@ func (s *sortedArray) SubVector(context ContextOffset) NGramVector {
 	}
 }
 
+// Iterate calls the iterator for each pair (word, count) of the vector
+func (s *sortedArray) Iterate(iterator func(word WordID, count WordCount)) {
+	for i, key := range s.keys {
+		iterator(getWordID(key), s.values[i])
+	}
+}
+
 // MarshalBinary encodes the receiver into a binary form and returns the result.
 func (s *sortedArray) MarshalBinary() ([]byte, error) {
 	var result bytes.Buffer
@@ -192,6 +201,11 @@ func getWordID(key key) WordID {
 	return utils.UnpackRight(key)
 }
 
+// getContext returns the context offset for the given key
+func getContext(key key) ContextOffset {
+	return utils.UnpackLeft(key)
+}
+
 func init() {
 	gob.Register(&sortedArray{})
 }
@@ -0,0 +1,126 @@
+package lm
+
+import (
+	"errors"
+	"math"
+)
+
+// PruneConfig describes the criteria of nGrams removal from a language model
+type PruneConfig struct {
+	// MinCounts holds the minimal count of an nGram for each order, starting from unigrams
+	MinCounts []WordCount `json:"minCounts"`
+	// Threshold is the minimal relative entropy (Stolcke criterion) an nGram should contribute to the model
+	Threshold float64 `json:"threshold"`
+}
+
+// ErrPruneUnsupportedModel tells that the given model can't be pruned
+var ErrPruneUnsupportedModel = errors.New("pruning is not supported for the given nGram model")
+
+// Prune creates a new NGramModel without nGrams that do not satisfy the given config.
+// An nGram is kept if its count is not less than the min count of the corresponding order
+// and removing it changes the model entropy by more than the threshold. NGrams that are
+// contexts of kept higher order nGrams are never removed.
+//
+// The entropy criterion follows A. Stolcke "Entropy-based Pruning of Backoff Language Models",
+// where the distribution of the removed nGram is replaced by the backoff score of the model.
+func Prune(model NGramModel, config PruneConfig) (NGramModel, error) {
+	m, ok := model.(*nGramModel)
+
+	if !ok {
+		return nil, ErrPruneUnsupportedModel
+	}
+
+	vectors := make([]*sortedArray, len(m.indices))
+
+	for i, index := range m.indices {
+		if vectors[i], ok = index.(*sortedArray); !ok {
+			return nil, ErrPruneUnsupportedModel
+		}
+	}
+
+	keep := make([][]bool, len(vectors))
+
+	for i := len(vectors) - 1; i >= 0; i-- {
+		keep[i] = make([]bool, len(vectors[i].keys))
+
+		// contexts of the kept nGrams must survive
+		if i+1 < len(vectors) {
+			for j, key := range vectors[i+1].keys {
+				if keep[i+1][j] {
+					keep[i][getContext(key)] = true
+				}
+			}
+		}
+
+		for j := range vectors[i].keys {
+			if !keep[i][j] {
+				keep[i][j] = satisfies(vectors, i, j, config)
+			}
+		}
+	}
+
+	indices := make([]NGramVector, len(vectors))
+	offsets := make([]ContextOffset, 0)
+
+	for i, vector := range vectors {
+		pruned := &sortedArray{
+			keys:   make([]key, 0, len(vector.keys)),
+			values: make([]WordCount, 0, len(vector.values)),
+			total:  vector.total,
+		}
+
+		nextOffsets := make([]ContextOffset, len(vector.keys))
+
+		for j, k := range vector.keys {
+			nextOffsets[j] = InvalidContextOffset
+
+			if !keep[i][j] {
+				continue
+			}
+
+			context := getContext(k)
+
+			if i > 0 {
+				context = offsets[context]
+			}
+
+			nextOffsets[j] = ContextOffset(len(pruned.keys))
+			pruned.keys = append(pruned.keys, makeKey(getWordID(k), context))
+			pruned.values = append(pruned.values, vector.values[j])
+		}
+
+		indices[i] = pruned
+		offsets = nextOffsets
+	}
+
+	return NewNGramModel(indices), nil
+}
+
+// satisfies tells whether the j-th nGram of the i-th vector satisfies the prune config
+func satisfies(vectors []*sortedArray, i, j int, config PruneConfig) bool {
+	if i < len(config.MinCounts) && vectors[i].values[j] < config.MinCounts[i] {
+		return false
+	}
+
+	if config.Threshold <= 0 || i == 0 {
+		return true
+	}
+
+	// counts holds the corpus count followed by the counts of each nGram prefix
+	counts := make([]WordCount, i+2)
+	counts[0] = vectors[0].total
+	offset := ContextOffset(j)
+
+	for k := i; k >= 0; k-- {
+		counts[k+1] = vectors[k].values[offset]
+		offset = getContext(vectors[k].keys[offset])
+	}
+
+	var (
+		score        = calcScore(counts)
+		backoffScore = calcScore(append(counts[:i+1:i+1], 0))
+		history      = float64(counts[i]) / float64(vectors[i-1].total)
+	)
+
+	return history*math.Exp(score)*(score-backoffScore) >= config.Threshold
+}
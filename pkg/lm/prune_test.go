@@ -0,0 +1,71 @@
+package lm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+func TestPrune(t *testing.T) {
+	indexer, err := buildIndexerWithInMemoryDictionary("testdata/fixtures/1-gm")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	directory, err := store.NewFSDirectory("testdata/fixtures")
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	model, err := NewGoogleNGramReader(3, indexer, directory).Read()
+
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	cases := []struct {
+		config   PruneConfig
+		nGrams   Sentence
+		expected float64
+	}{
+		{PruneConfig{}, Sentence{"i", "am", "sam"}, -0.6931},
+		{PruneConfig{MinCounts: []WordCount{1, 2, 2}}, Sentence{"i", "am", "sam"}, -1.3217},
+		{PruneConfig{MinCounts: []WordCount{1, 2, 2}}, Sentence{"i", "am"}, -0.4054},
+		{PruneConfig{MinCounts: []WordCount{1, 2, 2}}, Sentence{"i", "do"}, -2.8134},
+		{PruneConfig{MinCounts: []WordCount{1, 2, 2}}, Sentence{"green", "eggs"}, -3.9120},
+		{PruneConfig{MinCounts: []WordCount{1, 1, 2}}, Sentence{"<S>", "i", "am"}, -1.3217},
+		{PruneConfig{Threshold: 0.1}, Sentence{"i", "am", "sam"}, -1.3217},
+		{PruneConfig{Threshold: 0.1}, Sentence{"am", "sam", "</S>"}, -1.6094},
+		{PruneConfig{Threshold: 0.1}, Sentence{"i", "do", "not"}, 0},
+		{PruneConfig{Threshold: 0.1}, Sentence{"i", "do"}, -1.0986},
+		{PruneConfig{Threshold: 0.1}, Sentence{"green", "eggs"}, 0},
+	}
+
+	for _, c := range cases {
+		pruned, err := Prune(model, c.config)
+
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+
+		ids := make([]WordID, 0, len(c.nGrams))
+
+		for _, nGram := range c.nGrams {
+			id, _ := indexer.Get(nGram)
+			ids = append(ids, id)
+		}
+
+		if actual := pruned.Score(ids); math.Abs(actual-c.expected) >= tolerance {
+			t.Errorf(
+				"Test fail, for %v with %v expected score %v, got %v",
+				c.nGrams,
+				c.config,
+				c.expected,
+				actual,
+			)
+		}
+	}
+}
@@ -15,8 +15,10 @@ type Sentence = []Token
 // SentenceRetriever is an entity that is responsible for retrieving
 // sentences from the given source
 type SentenceRetriever interface {
-	// Retrieves and returns the next sentence from the source
+	// Retrieves and returns the next sentence from the source, nil at the end of the source
 	Retrieve() Sentence
+	// Err returns the first error that was encountered while reading the source
+	Err() error
 }
 
 // NewSentenceRetriever creates new instance of sentence retriever
@@ -49,6 +51,11 @@ func (r *retriever) Retrieve() Sentence {
 	return nil
 }
 
+// Err returns the first non-EOF error that was encountered by the scanner
+func (r *retriever) Err() error {
+	return r.scanner.Err()
+}
+
 // scanSentence is a split function for scanner.Split,
 // that returns each sentence of text
 func (r *retriever) scanSentence(data []byte, atEOF bool) (advance int, token []byte, err error) {
@@ -54,3 +54,41 @@ func TestSentenceRetrieve(t *testing.T) {
 		}
 	}
 }
+
+func TestSentenceRetrieveStems(t *testing.T) {
+	config := &Config{
+		Alphabet:   []string{"russian"},
+		Separators: []string{"."},
+		Stemmer:    "russian",
+	}
+
+	factory, err := config.GetRetrieverFactory()
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	retriever := factory(strings.NewReader("Красивые машины. Красивая машина"))
+	expected := []Sentence{{"красив", "машин"}, {"красив", "машин"}}
+	actual := []Sentence{}
+
+	for s := retriever.Retrieve(); s != nil; s = retriever.Retrieve() {
+		actual = append(actual, s)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Test fail, expected %v, got %v", expected, actual)
+	}
+}
+
+func TestRetrieverFactoryInvalidStemmer(t *testing.T) {
+	config := &Config{
+		Alphabet:   []string{"russian"},
+		Separators: []string{"."},
+		Stemmer:    "klingon",
+	}
+
+	if _, err := config.GetRetrieverFactory(); err == nil {
+		t.Errorf("Test fail, expected an error for the unknown stemmer")
+	}
+}
@@ -0,0 +1,68 @@
+package lm
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+// gzipMagic is the header that starts each gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// source is a reader of a corpus file
+type source struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// OpenSource opens the corpus file for the given path.
+// Gzip compressed files are decompressed on the fly.
+func OpenSource(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not open a source file %s: %v", path, err)
+	}
+
+	reader := bufio.NewReader(file)
+	header, err := reader.Peek(len(gzipMagic))
+
+	if err != nil && err != io.EOF {
+		file.Close()
+		return nil, fmt.Errorf("could not read a source file %s: %v", path, err)
+	}
+
+	if len(header) < len(gzipMagic) || header[0] != gzipMagic[0] || header[1] != gzipMagic[1] {
+		return &source{
+			Reader:  reader,
+			closers: []io.Closer{file},
+		}, nil
+	}
+
+	gzipReader, err := gzip.NewReader(reader)
+
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("could not decompress a source file %s: %v", path, err)
+	}
+
+	return &source{
+		Reader:  gzipReader,
+		closers: []io.Closer{gzipReader, file},
+	}, nil
+}
+
+// Close closes all the underlying readers and returns the first error
+func (s *source) Close() error {
+	var firstErr error
+
+	for _, closer := range s.closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,44 @@
+package lm
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSourceClose(t *testing.T) {
+	var (
+		errFirst  = errors.New("first")
+		errSecond = errors.New("second")
+		closed    []string
+	)
+
+	closer := func(name string, err error) closerFunc {
+		return func() error {
+			closed = append(closed, name)
+			return err
+		}
+	}
+
+	s := &source{
+		Reader:  strings.NewReader(""),
+		closers: []io.Closer{closer("gzip", errFirst), closer("file", errSecond)},
+	}
+
+	if err := s.Close(); err != errFirst {
+		t.Errorf("Test fail, expected %v, got %v", errFirst, err)
+	}
+
+	if strings.Join(closed, " ") != "gzip file" {
+		t.Errorf("Test fail, expected all the closers to be closed, got %v", closed)
+	}
+}
+
+// closerFunc is an adapter to use a function as an io.Closer
+type closerFunc func() error
+
+// Close implements io.Closer interface
+func (f closerFunc) Close() error {
+	return f()
+}
@@ -0,0 +1,33 @@
+package metric
+
+import "errors"
+
+// Names of the metrics
+const (
+	Jaccard = "Jaccard"
+	Cosine  = "Cosine"
+	Dice    = "Dice"
+	Exact   = "Exact"
+	Overlap = "Overlap"
+)
+
+// ErrMetricNotFound tells that there is no metric with the requested name
+var ErrMetricNotFound = errors.New("metric is not found")
+
+// GetMetric returns the metric with the given name
+func GetMetric(name string) (Metric, error) {
+	switch name {
+	case Jaccard:
+		return JaccardMetric(), nil
+	case Cosine:
+		return CosineMetric(), nil
+	case Dice:
+		return DiceMetric(), nil
+	case Exact:
+		return ExactMetric(), nil
+	case Overlap:
+		return OverlapMetric(), nil
+	default:
+		return nil, ErrMetricNotFound
+	}
+}
@@ -0,0 +1,83 @@
+package spellchecker
+
+import (
+	"math"
+
+	"github.com/suggest-go/suggest/pkg/lm"
+)
+
+const (
+	// DefaultConfidenceThreshold is the confidence above which the original token is kept
+	DefaultConfidenceThreshold = 0.5
+	// typoProbability is the prior probability that a token of the vocabulary is a misspelling
+	typoProbability = 0.05
+)
+
+// PredictionResult is the result of the spellchecker prediction
+type PredictionResult struct {
+	// Original is the predicted (the last) token of the query
+	Original string `json:"original"`
+	// KeepOriginal tells that the original token is correct and should not be replaced
+	KeepOriginal bool `json:"keepOriginal"`
+	// Confidence is the probability that the original token is spelled correctly
+	Confidence float64 `json:"confidence"`
+	// Candidates is the list of candidates for the original token
+	Candidates []Prediction `json:"candidates"`
+}
+
+// SetConfidenceThreshold sets the confidence above which the original token is kept
+func (s *SpellChecker) SetConfidenceThreshold(threshold float64) {
+	s.confidenceThreshold = threshold
+}
+
+// confidence returns the probability that the given word is spelled correctly. The word
+// competes with the corrections (fuzzy matches and splits) of the candidates, completions
+// are not corrections and don't take part. Each contender is scored by the lm score in the
+// context of the sequence, the error model score and the prior of a misspelling
+func (s *SpellChecker) confidence(seq []lm.WordID, word string, candidates []Prediction) (float64, error) {
+	id, err := s.model.GetWordID(word)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if id == lm.UnknownWordID {
+		return 0, nil
+	}
+
+	lmScore := s.conditionalScore(seq, []lm.WordID{id})
+	scores := []float64{lmScore + s.errorScore(word, word, 1) + math.Log(1-typoProbability)}
+
+	for _, c := range candidates {
+		if c.Value == word || c.Source == PrefixSource {
+			continue
+		}
+
+		scores = append(scores, c.LMScore+s.errorScore(word, c.Value, c.Similarity)+math.Log(typoProbability))
+	}
+
+	return softmax(scores)[0], nil
+}
+
+// softmax converts the given log scores into the probability distribution
+func softmax(scores []float64) []float64 {
+	max := math.Inf(-1)
+
+	for _, score := range scores {
+		max = math.Max(max, score)
+	}
+
+	sum := 0.0
+	result := make([]float64, len(scores))
+
+	for i, score := range scores {
+		result[i] = math.Exp(score - max)
+		sum += result[i]
+	}
+
+	for i := range result {
+		result[i] /= sum
+	}
+
+	return result
+}
@@ -0,0 +1,60 @@
+package spellchecker
+
+import (
+	"math"
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/lm"
+)
+
+func TestConfidence(t *testing.T) {
+	spellchecker := buildSpellChecker(t)
+
+	cases := []struct {
+		query        string
+		keepOriginal bool
+		minimum      float64
+		maximum      float64
+	}{
+		{"I am", true, 0.99, 1},
+		{"I am sam", true, 0.99, 1},
+		{"like", true, 0.99, 1},
+		{"ham", true, 0.5, 0.9},
+		{"i am ham", false, 0.1, 0.5},
+		{"I an", false, 0, 0},
+		{"i do not lke", false, 0, 0},
+	}
+
+	for _, c := range cases {
+		result, err := spellchecker.Predict(c.query, 5, 0.3)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if result.KeepOriginal != c.keepOriginal {
+			t.Errorf("Test fail, for %s expected keepOriginal %v, got %v", c.query, c.keepOriginal, result.KeepOriginal)
+		}
+
+		if result.Confidence < c.minimum || result.Confidence > c.maximum {
+			t.Errorf("Test fail, for %s expected confidence in [%v, %v], got %v", c.query, c.minimum, c.maximum, result.Confidence)
+		}
+	}
+
+	spellchecker.SetConfidenceThreshold(0.3)
+
+	if result, _ := spellchecker.Predict("i am ham", 5, 0.3); !result.KeepOriginal {
+		t.Errorf("Test fail, expected to keep the original with the lower threshold, got %v", result)
+	}
+}
+
+func TestSoftmax(t *testing.T) {
+	actual := softmax([]float64{math.Log(1), math.Log(3), lm.UnknownWordScore})
+	expected := []float64{0.25, 0.75, 0}
+
+	for i := range expected {
+		if math.Abs(actual[i]-expected[i]) > 1e-9 {
+			t.Errorf("Test fail, expected %v, got %v", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package spellchecker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/suggest-go/suggest/pkg/lm"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+const (
+	// beamWidth is the minimal number of hypotheses that survive each step of the beam search
+	beamWidth = 10
+	// tokenCandidatesLimit is the maximal number of fuzzy search candidates of each token
+	tokenCandidatesLimit = 10
+)
+
+// Correction is a corrected version of a sentence
+type Correction struct {
+	// Tokens is the corrected sequence of tokens
+	Tokens []string `json:"tokens"`
+	// Score is the sum of the lm score and the error model score of the correction
+	Score float64 `json:"score"`
+	// Corrections is the list of the replaced parts of the sentence
+	Corrections []TokenCorrection `json:"corrections"`
+}
+
+// tokenCandidate is an edge of the correction lattice, it replaces span tokens
+// of the sentence with the given words
+type tokenCandidate struct {
+	ids        []lm.WordID
+	values     []string
+	span       int
+	source     Source
+	errorScore float64
+}
+
+// hypothesis is a path in the correction lattice
+type hypothesis struct {
+	ids         []lm.WordID
+	values      []string
+	corrections []TokenCorrection
+	lmScore     float64
+	errorScore  float64
+	score       float64
+}
+
+// Correct corrects the given sentence and returns topK the most probable corrections.
+// Each token is replaced with the candidates of the fuzzy search, split into several
+// vocabulary words or joined with the next tokens into one vocabulary word. The best
+// paths of the correction lattice are selected by the beam search over the language
+// model score and the error model score
+func (s *SpellChecker) Correct(sentence string, topK int, similarity float64) ([]Correction, error) {
+	if err := s.checkLimits(sentence, topK); err != nil {
+		return nil, err
+	}
+
+	tokens := s.tokenizer.Tokenize(sentence)
+
+	if len(tokens) == 0 {
+		return []Correction{}, nil
+	}
+
+	width := beamWidth
+
+	if topK > width {
+		width = topK
+	}
+
+	spans := tokenSpans(sentence, tokens, s.normalizer)
+
+	// beams[i] holds the hypotheses that cover the first i tokens of the sentence
+	beams := make([][]hypothesis, len(tokens)+1)
+	beams[0] = []hypothesis{{}}
+
+	for i := range tokens {
+		beam := prune(beams[i], width)
+		candidates, err := s.latticeCandidates(tokens, i, similarity)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, h := range beam {
+			for _, c := range candidates {
+				ids := append(append(make([]lm.WordID, 0, len(h.ids)+len(c.ids)), h.ids...), c.ids...)
+				values := append(append(make([]string, 0, len(h.values)+len(c.values)), h.values...), c.values...)
+				corrections := h.corrections
+				// the words of the edge are scored after the prefix, the end of the sentence is scored in the last beam
+				lmScore := h.lmScore + s.conditionalScore(h.ids, c.ids)
+				errorScore := h.errorScore + c.errorScore
+
+				original, value := strings.Join(tokens[i:i+c.span], " "), strings.Join(c.values, " ")
+
+				if original != value {
+					corrections = append(make([]TokenCorrection, 0, len(h.corrections)+1), h.corrections...)
+					corrections = append(corrections, TokenCorrection{
+						Original: original,
+						Value:    value,
+						Source:   c.source,
+						Span:     Span{Start: spans[i].Start, End: spans[i+c.span-1].End},
+					})
+				}
+
+				beams[i+c.span] = append(beams[i+c.span], hypothesis{
+					ids:         ids,
+					values:      values,
+					corrections: corrections,
+					lmScore:     lmScore,
+					errorScore:  errorScore,
+					score:       lmScore + errorScore,
+				})
+			}
+		}
+	}
+
+	sentences := beams[len(tokens)]
+
+	for i := range sentences {
+		sentences[i].score += s.model.ScoreEnd(sentences[i].ids)
+	}
+
+	beam := prune(sentences, topK)
+	result := make([]Correction, 0, len(beam))
+
+	for _, h := range beam {
+		corrections := h.corrections
+
+		if corrections == nil {
+			corrections = []TokenCorrection{}
+		}
+
+		result = append(result, Correction{
+			Tokens:      h.values,
+			Score:       h.score,
+			Corrections: corrections,
+		})
+	}
+
+	return result, nil
+}
+
+// latticeCandidates returns the edges of the correction lattice that start from the given position
+func (s *SpellChecker) latticeCandidates(tokens []string, position int, similarity float64) ([]tokenCandidate, error) {
+	token := tokens[position]
+	candidates, err := s.tokenCandidates(token, similarity)
+
+	if err != nil {
+		return nil, err
+	}
+
+	splits, err := s.split(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, split := range splits {
+		candidates = append(candidates, tokenCandidate{
+			ids:        split.ids,
+			values:     split.values,
+			span:       1,
+			source:     SplitSource,
+			errorScore: s.errorScore(token, strings.Join(split.values, " "), 1),
+		})
+	}
+
+	joins, err := s.joinCandidates(tokens, position)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(candidates, joins...), nil
+}
+
+// prune removes duplicates from the given hypotheses and returns at most width the best of them
+func prune(hypotheses []hypothesis, width int) []hypothesis {
+	sort.SliceStable(hypotheses, func(i, j int) bool {
+		return hypotheses[i].score > hypotheses[j].score
+	})
+
+	seen := make(map[string]struct{}, len(hypotheses))
+	result := make([]hypothesis, 0, width)
+
+	for _, h := range hypotheses {
+		if len(result) == width {
+			break
+		}
+
+		key := strings.Join(h.values, " ")
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		result = append(result, h)
+	}
+
+	return result
+}
+
+// tokenCandidates returns the list of correction candidates for the given token.
+// The token itself is a candidate if it belongs to the vocabulary of the language model
+func (s *SpellChecker) tokenCandidates(token string, similarity float64) ([]tokenCandidate, error) {
+	id, err := s.model.GetWordID(token)
+
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]tokenCandidate, 0, tokenCandidatesLimit+1)
+
+	if id != lm.UnknownWordID {
+		candidates = append(candidates, tokenCandidate{
+			ids:        []lm.WordID{id},
+			values:     []string{token},
+			span:       1,
+			errorScore: s.errorScore(token, token, 1),
+		})
+	}
+
+	config, err := suggest.NewSearchConfig(token, tokenCandidatesLimit, metric.CosineMetric(), similarity)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fuzzyCandidates, err := s.index.Suggest(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range fuzzyCandidates {
+		if c.Key == id {
+			continue
+		}
+
+		value, err := s.dict.Get(c.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, tokenCandidate{
+			ids:        []lm.WordID{c.Key},
+			values:     []string{value},
+			span:       1,
+			source:     FuzzySource,
+			errorScore: s.errorScore(token, value, c.Score),
+		})
+	}
+
+	// there is nothing to offer, so we keep the token as is
+	if len(candidates) == 0 {
+		candidates = append(candidates, tokenCandidate{
+			ids:        []lm.WordID{lm.UnknownWordID},
+			values:     []string{token},
+			span:       1,
+			errorScore: 0,
+		})
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,210 @@
+package spellchecker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/suggest-go/suggest/pkg/utils"
+)
+
+// ErrorModel is the channel model of the spellchecker, it tells
+// how likely the typo was made while typing the given word
+type ErrorModel interface {
+	// Score returns the log probability P(typo | word)
+	Score(typo, word string) float64
+}
+
+// editKind represents the kind of an edit operation
+type editKind uint8
+
+const (
+	substitution editKind = iota
+	deletion
+	insertion
+	transposition
+)
+
+// boundary is a virtual character that precedes the first character of a word
+const boundary = rune(0)
+
+// edit is an edit operation that transforms a word into a typo.
+// Depending on the kind, (x, y) means:
+// substitution - y was typed as x, deletion - xy was typed as x,
+// insertion - x was typed as xy, transposition - xy was typed as yx
+type edit struct {
+	kind editKind
+	x, y rune
+}
+
+// confusionErrorModel implements ErrorModel with the edit operation confusion matrices,
+// inspired by Kernighan, Church, Gale "A Spelling Correction Program Based on a Noisy Channel Model"
+type confusionErrorModel struct {
+	edits    map[edit]int
+	unigrams map[rune]int
+	bigrams  map[rune]map[rune]int
+	alphabet int
+}
+
+// ReadErrorModel trains an error model on the pairs of the given file
+func ReadErrorModel(path string) (ErrorModel, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open an error model file: %v", err)
+	}
+
+	defer file.Close()
+
+	return TrainErrorModel(file)
+}
+
+// TrainErrorModel trains an error model on the pairs (misspelling, correction),
+// one tab separated pair per line
+func TrainErrorModel(reader io.Reader) (ErrorModel, error) {
+	model := &confusionErrorModel{
+		edits:    make(map[edit]int),
+		unigrams: make(map[rune]int),
+		bigrams:  make(map[rune]map[rune]int),
+	}
+
+	scanner := bufio.NewScanner(reader)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" {
+			continue
+		}
+
+		pair := strings.Split(text, "\t")
+
+		if len(pair) != 2 {
+			pair = strings.Fields(text)
+		}
+
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("invalid pair on the line %d: %s", line, text)
+		}
+
+		model.train(strings.ToLower(pair[0]), strings.ToLower(pair[1]))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read error model pairs: %v", err)
+	}
+
+	model.alphabet = len(model.unigrams) + 1
+
+	return model, nil
+}
+
+// train registers the edit operations of the given pair
+func (m *confusionErrorModel) train(typo, word string) {
+	chars := []rune(word)
+	prev := boundary
+	m.unigrams[boundary]++
+
+	for _, char := range chars {
+		m.unigrams[char]++
+
+		if m.bigrams[prev] == nil {
+			m.bigrams[prev] = make(map[rune]int)
+		}
+
+		m.bigrams[prev][char]++
+		prev = char
+	}
+
+	for _, e := range align([]rune(typo), chars) {
+		m.edits[e]++
+	}
+}
+
+// Score returns the log probability P(typo | word)
+func (m *confusionErrorModel) Score(typo, word string) float64 {
+	score := 0.0
+
+	for _, e := range align([]rune(typo), []rune(word)) {
+		score += math.Log(float64(m.edits[e]+1) / float64(m.context(e)+m.alphabet))
+	}
+
+	return score
+}
+
+// context returns how many times the context of the given edit appears in the training words
+func (m *confusionErrorModel) context(e edit) int {
+	switch e.kind {
+	case substitution:
+		return m.unigrams[e.y]
+	case insertion:
+		return m.unigrams[e.x]
+	default:
+		return m.bigrams[e.x][e.y]
+	}
+}
+
+// align returns the list of edit operations of the optimal alignment
+// (Damerau-Levenshtein distance) that transforms the word into the typo
+func align(typo, word []rune) []edit {
+	n, m := len(word), len(typo)
+	d := make([][]int, n+1)
+
+	for i := range d {
+		d[i] = make([]int, m+1)
+		d[i][0] = i
+	}
+
+	for j := 0; j <= m; j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			cost := 1
+
+			if word[i-1] == typo[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = utils.Min(utils.Min(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && word[i-1] == typo[j-2] && word[i-2] == typo[j-1] && word[i-1] != word[i-2] {
+				d[i][j] = utils.Min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	edits := make([]edit, 0, d[n][m])
+	charAt := func(s []rune, i int) rune {
+		if i < 0 {
+			return boundary
+		}
+
+		return s[i]
+	}
+
+	for i, j := n, m; i > 0 || j > 0; {
+		switch {
+		case i > 0 && j > 0 && word[i-1] == typo[j-1] && d[i][j] == d[i-1][j-1]:
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && d[i][j] == d[i-1][j-1]+1:
+			edits = append(edits, edit{substitution, typo[j-1], word[i-1]})
+			i, j = i-1, j-1
+		case i > 1 && j > 1 && word[i-1] == typo[j-2] && word[i-2] == typo[j-1] && d[i][j] == d[i-2][j-2]+1:
+			edits = append(edits, edit{transposition, word[i-2], word[i-1]})
+			i, j = i-2, j-2
+		case i > 0 && d[i][j] == d[i-1][j]+1:
+			edits = append(edits, edit{deletion, charAt(word, i-2), word[i-1]})
+			i--
+		default:
+			edits = append(edits, edit{insertion, charAt(typo, j-2), typo[j-1]})
+			j--
+		}
+	}
+
+	return edits
+}
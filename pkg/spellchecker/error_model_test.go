@@ -0,0 +1,77 @@
+package spellchecker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestErrorModel(t *testing.T) {
+	pairs := strings.Join([]string{
+		"teh\tthe",
+		"adress\taddress",
+		"recieve\treceive",
+		"acheive\tachieve",
+		"beleive\tbelieve",
+		"untill\tuntil",
+		"accomodate\taccommodate",
+		"wich\twhich",
+	}, "\n")
+
+	model, err := TrainErrorModel(strings.NewReader(pairs))
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score := model.Score("word", "word"); score != 0 {
+		t.Errorf("Test fail, expected zero score for the same words, got %v", score)
+	}
+
+	cases := []struct {
+		typo, likely, unlikely string
+	}{
+		{"freind", "friend", "fiend"},
+		{"adres", "adress", "ares"},
+		{"teh", "the", "ten"},
+		{"mesage", "message", "mirage"},
+	}
+
+	for _, c := range cases {
+		likely, unlikely := model.Score(c.typo, c.likely), model.Score(c.typo, c.unlikely)
+
+		if likely <= unlikely {
+			t.Errorf(
+				"Test fail, expected P(%s|%s) = %v to be greater than P(%s|%s) = %v",
+				c.typo, c.likely, likely, c.typo, c.unlikely, unlikely,
+			)
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	cases := []struct {
+		typo, word string
+		expected   []edit
+	}{
+		{"teh", "the", []edit{{transposition, 'h', 'e'}}},
+		{"adres", "address", []edit{{deletion, 'e', 's'}, {deletion, 'a', 'd'}}},
+		{"untill", "until", []edit{{insertion, 'i', 'l'}}},
+		{"wich", "which", []edit{{deletion, 'w', 'h'}}},
+		{"cat", "cut", []edit{{substitution, 'a', 'u'}}},
+	}
+
+	for _, c := range cases {
+		actual := align([]rune(c.typo), []rune(c.word))
+
+		if len(actual) != len(c.expected) {
+			t.Errorf("Test fail, for (%s, %s) expected %v, got %v", c.typo, c.word, c.expected, actual)
+			continue
+		}
+
+		for i := range actual {
+			if actual[i] != c.expected[i] {
+				t.Errorf("Test fail, for (%s, %s) expected %v, got %v", c.typo, c.word, c.expected, actual)
+			}
+		}
+	}
+}
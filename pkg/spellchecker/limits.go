@@ -0,0 +1,66 @@
+package spellchecker
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxTopK is the max topK of a query to a spellchecker that doesn't declare it
+	DefaultMaxTopK = 100
+	// DefaultMaxQueryLength is the max number of characters of a query to a spellchecker that doesn't declare it
+	DefaultMaxQueryLength = 1000
+	// DefaultMaxSplitLength is the max number of characters of a token that is split into words
+	DefaultMaxSplitLength = 32
+)
+
+var (
+	// ErrInvalidTopK tells that the topK of a query is not a positive integer
+	ErrInvalidTopK = errors.New("topK should be a positive integer")
+	// ErrTopKLimit tells that the topK of a query exceeds the limit of the spellchecker
+	ErrTopKLimit = errors.New("topK exceeds the limit of the spellchecker")
+	// ErrQueryLengthLimit tells that a query is longer than the limit of the spellchecker
+	ErrQueryLengthLimit = errors.New("query exceeds the max length of the spellchecker")
+)
+
+// Limits bound the work of a query to a spellchecker
+type Limits struct {
+	// MaxTopK is the max allowed topK, it is also the max width of the correction beam
+	MaxTopK int
+	// MaxQueryLength is the max allowed number of characters of a query
+	MaxQueryLength int
+	// MaxSplitLength is the max number of characters of a token that is split into words,
+	// the longer tokens are not split
+	MaxSplitLength int
+}
+
+// DefaultLimits returns the limits of a spellchecker that doesn't declare its own ones
+func DefaultLimits() Limits {
+	return Limits{
+		MaxTopK:        DefaultMaxTopK,
+		MaxQueryLength: DefaultMaxQueryLength,
+		MaxSplitLength: DefaultMaxSplitLength,
+	}
+}
+
+// SetLimits sets the limits of the queries
+func (s *SpellChecker) SetLimits(limits Limits) {
+	s.limits = limits
+}
+
+// checkLimits returns an error if the topK is not positive or the query or the topK exceeds the limits
+func (s *SpellChecker) checkLimits(query string, topK int) error {
+	if topK <= 0 {
+		return ErrInvalidTopK
+	}
+
+	if topK > s.limits.MaxTopK {
+		return ErrTopKLimit
+	}
+
+	if utf8.RuneCountInString(query) > s.limits.MaxQueryLength {
+		return ErrQueryLengthLimit
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package spellchecker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	spellchecker := buildSpellChecker(t)
+	spellchecker.SetLimits(Limits{MaxTopK: 10, MaxQueryLength: 20, MaxSplitLength: 6})
+
+	cases := []struct {
+		query    string
+		topK     int
+		expected error
+	}{
+		{"i am sam", 10, nil},
+		{"i am sam", 11, ErrTopKLimit},
+		{"i am sam", 0, ErrInvalidTopK},
+		{"i am sam", -1, ErrInvalidTopK},
+		{strings.Repeat("a", 20), 1, nil},
+		{strings.Repeat("a", 21), 1, ErrQueryLengthLimit},
+		{strings.Repeat("ф", 21), 1, ErrQueryLengthLimit},
+	}
+
+	for _, c := range cases {
+		if _, err := spellchecker.Predict(c.query, c.topK, 0.3); err != c.expected {
+			t.Errorf("Test fail of predict %s, expected %v, got %v", c.query, c.expected, err)
+		}
+
+		if _, err := spellchecker.Correct(c.query, c.topK, 0.3); err != c.expected {
+			t.Errorf("Test fail of correct %s, expected %v, got %v", c.query, c.expected, err)
+		}
+
+		if _, err := spellchecker.Next(c.query, c.topK); err != c.expected {
+			t.Errorf("Test fail of next %s, expected %v, got %v", c.query, c.expected, err)
+		}
+	}
+
+	// "eggsand" is longer than the max split length
+	corrections, err := spellchecker.Correct("green eggsand ham", 1, 0.3)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, correction := range corrections[0].Corrections {
+		if correction.Source == SplitSource {
+			t.Errorf("Test fail, expected no splits of the long token, got %v", correction)
+		}
+	}
+
+	spellchecker.SetLimits(Limits{MaxTopK: 10, MaxQueryLength: 20, MaxSplitLength: 7})
+	corrections, _ = spellchecker.Correct("green eggsand ham", 1, 0.3)
+
+	if len(corrections[0].Corrections) != 1 || corrections[0].Corrections[0].Source != SplitSource {
+		t.Errorf("Test fail, expected the split of the token, got %v", corrections[0].Corrections)
+	}
+}
@@ -0,0 +1,142 @@
+package spellchecker
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/lm"
+)
+
+// Source tells how a prediction candidate was found
+type Source string
+
+const (
+	// PrefixSource means that the candidate is a completion of the word
+	PrefixSource Source = "prefix"
+	// FuzzySource means that the candidate was found by the fuzzy search
+	FuzzySource Source = "fuzzy"
+	// SplitSource means that the candidate is a split of the word into vocabulary words
+	SplitSource Source = "split"
+	// JoinSource means that the candidate is a join of several tokens into one vocabulary word
+	JoinSource Source = "join"
+)
+
+// Span is a [Start, End) range of characters (runes) of a query
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Prediction is a candidate of the spellchecker prediction
+type Prediction struct {
+	// Value is the candidate that replaces the span of the query
+	Value string `json:"value"`
+	// Original is the replaced token of the query
+	Original string `json:"original"`
+	// Source tells how the candidate was found
+	Source Source `json:"source"`
+	// LMScore is the log probability of the candidate in the context of the preceding tokens
+	LMScore float64 `json:"lmScore"`
+	// Similarity is the similarity between the original token and the candidate
+	Similarity float64 `json:"similarity"`
+	// Span is the part of the query replaced with the candidate
+	Span Span `json:"span"`
+}
+
+// TokenCorrection is a replacement of a part of a sentence
+type TokenCorrection struct {
+	// Original is the replaced part of the sentence
+	Original string `json:"original"`
+	// Value is the replacement
+	Value string `json:"value"`
+	// Source tells how the replacement was found
+	Source Source `json:"source"`
+	// Span is the replaced part of the sentence
+	Span Span `json:"span"`
+}
+
+// tokenSpans returns the spans of the given tokens in the text. Tokens are expected to be
+// substrings of the lower cased normalized text in the same order. The positions of the
+// normalized text are mapped back to the characters of the text they are produced from
+func tokenSpans(text string, tokens []string, normalizer analysis.Normalizer) []Span {
+	chars, origins := normalizeChars(text, normalizer)
+	spans := make([]Span, 0, len(tokens))
+	offset, end := 0, 0
+
+	for _, token := range tokens {
+		t := []rune(token)
+		start := indexRunes(chars, t, offset)
+
+		if start < 0 || len(t) == 0 {
+			// should never happen, the token gets an empty span after the previous one
+			spans = append(spans, Span{Start: end, End: end})
+			continue
+		}
+
+		offset = start + len(t)
+		end = origins[offset-1].End
+		spans = append(spans, Span{Start: origins[start].Start, End: end})
+	}
+
+	return spans
+}
+
+// normalizeChars returns the lower cased normalized text and the span of the original characters
+// each of its characters is produced from. A character is normalized together with its combining
+// marks, so "é" written as "e" and the acute accent is composed or stripped as a whole
+func normalizeChars(text string, normalizer analysis.Normalizer) ([]rune, []Span) {
+	runes := []rune(text)
+	chars := make([]rune, 0, len(runes))
+	origins := make([]Span, 0, len(runes))
+
+	for start := 0; start < len(runes); {
+		end := start + 1
+
+		for end < len(runes) && unicode.Is(unicode.Mn, runes[end]) {
+			end++
+		}
+
+		for _, r := range strings.ToLower(normalizer.Normalize(string(runes[start:end]))) {
+			chars = append(chars, r)
+			origins = append(origins, Span{Start: start, End: end})
+		}
+
+		start = end
+	}
+
+	return chars, origins
+}
+
+// indexRunes returns the index of the first instance of needle in haystack starting from the offset
+func indexRunes(haystack, needle []rune, offset int) int {
+	for i := offset; i+len(needle) <= len(haystack); i++ {
+		found := true
+
+		for j, r := range needle {
+			if haystack[i+j] != r {
+				found = false
+				break
+			}
+		}
+
+		if found {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// conditionalScore returns the lm log probability of the words that follow after the given sequence
+func (s *SpellChecker) conditionalScore(seq, words []lm.WordID) float64 {
+	context := append(make([]lm.WordID, 0, len(seq)+len(words)), seq...)
+	score := 0.0
+
+	for _, word := range words {
+		score += s.model.ScoreNext(context, word)
+		context = append(context, word)
+	}
+
+	return score
+}
@@ -0,0 +1,42 @@
+package spellchecker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/analysis"
+)
+
+func TestTokenSpans(t *testing.T) {
+	normalizer, err := analysis.NewNormalizer(analysis.NormalizationConfig{
+		Steps: []analysis.NormalizationStep{analysis.NFKC, analysis.Lowercase, analysis.StripAccents},
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		text       string
+		tokens     []string
+		normalizer analysis.Normalizer
+		expected   []Span
+	}{
+		{"I am Sam", []string{"i", "am", "sam"}, analysis.NewIdentityNormalizer(), []Span{{0, 1}, {2, 4}, {5, 8}}},
+		{"  Sam,  sam!", []string{"sam", "sam"}, analysis.NewIdentityNormalizer(), []Span{{2, 5}, {8, 11}}},
+		{"Привет, мир", []string{"привет", "мир"}, analysis.NewIdentityNormalizer(), []Span{{0, 6}, {8, 11}}},
+		{"", []string{}, analysis.NewIdentityNormalizer(), []Span{}},
+		{"ＮＩＳＳＡＮ ｎｏｔｅ", []string{"nissan", "note"}, normalizer, []Span{{0, 6}, {7, 11}}},
+		{"Café au lait", []string{"cafe", "au", "lait"}, normalizer, []Span{{0, 4}, {5, 7}, {8, 12}}},
+		{"cafe\u0301 cre\u0300me", []string{"cafe", "creme"}, normalizer, []Span{{0, 5}, {6, 12}}},
+		{"ﬁne cafe", []string{"fine", "cafe"}, normalizer, []Span{{0, 3}, {4, 8}}},
+	}
+
+	for _, c := range cases {
+		actual := tokenSpans(c.text, c.tokens, c.normalizer)
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Test fail, for %s expected %v, got %v", c.text, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package spellchecker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/suggest-go/suggest/pkg/lm"
+)
+
+const (
+	// maxSplitParts is the maximal number of words a token can be split into
+	maxSplitParts = 3
+	// maxJoinTokens is the maximal number of adjacent tokens that can be joined into one word
+	maxJoinTokens = 3
+)
+
+// segmentation is a sequence of vocabulary words that replaces a part of a sentence
+type segmentation struct {
+	ids    []lm.WordID
+	values []string
+}
+
+// split returns all the ways to split the given token into 2..maxSplitParts words
+// of the language model vocabulary. The tokens longer than MaxSplitLength are not split
+func (s *SpellChecker) split(token string) ([]segmentation, error) {
+	chars := []rune(token)
+
+	if len(chars) > s.limits.MaxSplitLength {
+		return []segmentation{}, nil
+	}
+
+	ids := map[string]lm.WordID{}
+
+	// wordID returns the vocabulary id of the substring, the lookups are cached per call
+	wordID := func(from, to int) (lm.WordID, error) {
+		word := string(chars[from:to])
+
+		if id, ok := ids[word]; ok {
+			return id, nil
+		}
+
+		id, err := s.model.GetWordID(word)
+
+		if err != nil {
+			return lm.UnknownWordID, err
+		}
+
+		ids[word] = id
+
+		return id, nil
+	}
+
+	result := []segmentation{}
+	current := segmentation{}
+
+	var walk func(from, parts int) error
+
+	walk = func(from, parts int) error {
+		if from == len(chars) {
+			if parts > 1 {
+				result = append(result, segmentation{
+					ids:    append([]lm.WordID{}, current.ids...),
+					values: append([]string{}, current.values...),
+				})
+			}
+
+			return nil
+		}
+
+		if parts == maxSplitParts {
+			return nil
+		}
+
+		for to := from + 1; to <= len(chars); to++ {
+			// the whole token is not a split
+			if from == 0 && to == len(chars) {
+				continue
+			}
+
+			id, err := wordID(from, to)
+
+			if err != nil {
+				return err
+			}
+
+			if id == lm.UnknownWordID {
+				continue
+			}
+
+			current.ids = append(current.ids, id)
+			current.values = append(current.values, string(chars[from:to]))
+
+			if err := walk(to, parts+1); err != nil {
+				return err
+			}
+
+			current.ids = current.ids[:len(current.ids)-1]
+			current.values = current.values[:len(current.values)-1]
+		}
+
+		return nil
+	}
+
+	if err := walk(0, 0); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// splitCandidates returns the splits of the last word of the sentence sorted by the lm score
+// of the whole sentence
+func (s *SpellChecker) splitCandidates(seq []lm.WordID, word string) ([]segmentation, error) {
+	splits, err := s.split(word)
+
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(splits))
+
+	for i, split := range splits {
+		ids := append(append(make([]lm.WordID, 0, len(seq)+len(split.ids)), seq...), split.ids...)
+		scores[i] = s.model.ScoreWordIDs(ids)
+	}
+
+	sort.Stable(&segmentationSorter{splits: splits, scores: scores})
+
+	return splits, nil
+}
+
+// joinCandidates returns the candidates that are made by joining the token at the given position
+// with the next (up to maxJoinTokens) tokens. Only joins that form a vocabulary word are returned
+func (s *SpellChecker) joinCandidates(tokens []string, position int) ([]tokenCandidate, error) {
+	candidates := []tokenCandidate{}
+
+	for span := 2; span <= maxJoinTokens && position+span <= len(tokens); span++ {
+		parts := tokens[position : position+span]
+		word := strings.Join(parts, "")
+		id, err := s.model.GetWordID(word)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if id == lm.UnknownWordID {
+			continue
+		}
+
+		candidates = append(candidates, tokenCandidate{
+			ids:        []lm.WordID{id},
+			values:     []string{word},
+			span:       span,
+			source:     JoinSource,
+			errorScore: s.errorScore(strings.Join(parts, " "), word, 1),
+		})
+	}
+
+	return candidates, nil
+}
+
+// segmentationSorter sorts segmentations by their scores in descending order
+type segmentationSorter struct {
+	splits []segmentation
+	scores []float64
+}
+
+// Len is the number of elements in the collection
+func (s *segmentationSorter) Len() int {
+	return len(s.splits)
+}
+
+// Less reports whether the element with index i should sort before the element with index j
+func (s *segmentationSorter) Less(i, j int) bool {
+	return s.scores[i] > s.scores[j]
+}
+
+// Swap swaps the elements with indexes i and j
+func (s *segmentationSorter) Swap(i, j int) {
+	s.splits[i], s.splits[j] = s.splits[j], s.splits[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
@@ -0,0 +1,40 @@
+package spellchecker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	spellchecker := buildSpellChecker(t)
+
+	cases := []struct {
+		token    string
+		expected [][]string
+	}{
+		{"iam", [][]string{{"i", "am"}}},
+		{"samiam", [][]string{{"sam", "i", "am"}}},
+		{"donotlike", [][]string{{"do", "not", "like"}}},
+		{"greeneggsandham", [][]string{}},
+		{"sam", [][]string{}},
+		{"xyz", [][]string{}},
+	}
+
+	for _, c := range cases {
+		splits, err := spellchecker.split(c.token)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		actual := make([][]string, 0, len(splits))
+
+		for _, split := range splits {
+			actual = append(actual, split.values)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Test fail, for %s expected %v, got %v", c.token, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package spellchecker
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/suggest-go/suggest/pkg/alphabet"
+)
+
+var (
+	// ErrLanguageNotFound tells that the requested language is not hosted by the service
+	ErrLanguageNotFound = errors.New("language is not found")
+	// ErrNoLanguages tells that the service has no languages to route a query to
+	ErrNoLanguages = errors.New("there are no languages in the service")
+)
+
+// language is a spellchecker with an alphabet used for the language detection
+type language struct {
+	alphabet     alphabet.Alphabet
+	spellchecker *SpellChecker
+}
+
+// Service hosts several spellcheckers, one per language, and routes queries between them
+type Service struct {
+	sync.RWMutex
+	languages map[string]language
+	// names keeps the order of languages, the first one is the default language
+	names []string
+}
+
+// NewService creates an empty spellchecker Service
+func NewService() *Service {
+	return &Service{
+		languages: make(map[string]language),
+		names:     []string{},
+	}
+}
+
+// AddSpellChecker adds (or replaces) a spellchecker of the given language. The alphabet
+// is used to detect the language of a query when it is not specified explicitly
+func (s *Service) AddSpellChecker(lang string, alphabet alphabet.Alphabet, spellchecker *SpellChecker) {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.languages[lang]; !ok {
+		s.names = append(s.names, lang)
+	}
+
+	s.languages[lang] = language{
+		alphabet:     alphabet,
+		spellchecker: spellchecker,
+	}
+}
+
+// Replace atomically replaces the languages of the service with the languages of the given
+// service. The languages that are not hosted by the given service are dropped
+func (s *Service) Replace(other *Service) {
+	if s == other {
+		return
+	}
+
+	other.RLock()
+	languages := make(map[string]language, len(other.languages))
+
+	for name, l := range other.languages {
+		languages[name] = l
+	}
+
+	names := append([]string{}, other.names...)
+	other.RUnlock()
+
+	s.Lock()
+	s.languages = languages
+	s.names = names
+	s.Unlock()
+}
+
+// GetLanguages returns the managed list of languages
+func (s *Service) GetLanguages() []string {
+	s.RLock()
+	defer s.RUnlock()
+
+	return append([]string{}, s.names...)
+}
+
+// GetSpellChecker returns the spellchecker of the given language. If the language is empty
+// it is detected by the script of the query: the language which alphabet covers the most
+// characters of the query wins, ties are resolved in favour of the earlier added language
+func (s *Service) GetSpellChecker(lang, query string) (*SpellChecker, error) {
+	_, spellchecker, err := s.ResolveSpellChecker(lang, query)
+
+	return spellchecker, err
+}
+
+// ResolveSpellChecker works as GetSpellChecker and also returns the name of the chosen language
+func (s *Service) ResolveSpellChecker(lang, query string) (string, *SpellChecker, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	if len(s.names) == 0 {
+		return "", nil, ErrNoLanguages
+	}
+
+	if lang != "" {
+		l, ok := s.languages[lang]
+
+		if !ok {
+			return "", nil, ErrLanguageNotFound
+		}
+
+		return lang, l.spellchecker, nil
+	}
+
+	best, bestCount := s.names[0], 0
+
+	for _, name := range s.names {
+		count := 0
+		alphabet := s.languages[name].alphabet
+
+		for _, char := range query {
+			if alphabet.Has(char) {
+				count++
+			}
+		}
+
+		if count > bestCount {
+			best, bestCount = name, count
+		}
+	}
+
+	return best, s.languages[best].spellchecker, nil
+}
@@ -0,0 +1,121 @@
+package spellchecker
+
+import (
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/alphabet"
+)
+
+func TestServiceRouting(t *testing.T) {
+	service := NewService()
+
+	if _, err := service.GetSpellChecker("", "query"); err != ErrNoLanguages {
+		t.Errorf("Test fail, expected %v, got %v", ErrNoLanguages, err)
+	}
+
+	en, ru := buildSpellChecker(t), buildSpellChecker(t)
+
+	service.AddSpellChecker("en", alphabet.CreateAlphabet([]string{"english", "numbers"}), en)
+	service.AddSpellChecker("ru", alphabet.CreateAlphabet([]string{"russian", "numbers"}), ru)
+
+	cases := []struct {
+		lang     string
+		query    string
+		expected *SpellChecker
+	}{
+		{"", "green eggs", en},
+		{"", "зеленые яйца", ru},
+		{"", "зеленые eggs и ветчина", ru},
+		{"", "123", en},
+		{"", "", en},
+		{"ru", "green eggs", ru},
+		{"en", "зеленые яйца", en},
+	}
+
+	for _, c := range cases {
+		actual, err := service.GetSpellChecker(c.lang, c.query)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if actual != c.expected {
+			t.Errorf("Test fail, for (%s, %s) got the wrong spellchecker", c.lang, c.query)
+		}
+	}
+
+	if _, err := service.GetSpellChecker("de", "query"); err != ErrLanguageNotFound {
+		t.Errorf("Test fail, expected %v, got %v", ErrLanguageNotFound, err)
+	}
+
+	service.AddSpellChecker("en", alphabet.CreateAlphabet([]string{"english"}), ru)
+
+	if languages := service.GetLanguages(); len(languages) != 2 || languages[0] != "en" || languages[1] != "ru" {
+		t.Errorf("Test fail, expected [en ru], got %v", languages)
+	}
+
+	if actual, _ := service.GetSpellChecker("en", ""); actual != ru {
+		t.Errorf("Test fail, expected the replaced spellchecker")
+	}
+}
+
+func TestResolveSpellChecker(t *testing.T) {
+	service := NewService()
+	en, ru := buildSpellChecker(t), buildSpellChecker(t)
+
+	service.AddSpellChecker("en", alphabet.CreateAlphabet([]string{"english", "numbers"}), en)
+	service.AddSpellChecker("ru", alphabet.CreateAlphabet([]string{"russian", "numbers"}), ru)
+
+	cases := []struct {
+		lang     string
+		query    string
+		expected string
+	}{
+		{"", "green eggs", "en"},
+		{"", "зеленые яйца", "ru"},
+		{"ru", "green eggs", "ru"},
+	}
+
+	for _, c := range cases {
+		actual, checker, err := service.ResolveSpellChecker(c.lang, c.query)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if actual != c.expected || checker == nil {
+			t.Errorf("Test fail, expected %v, got %v", c.expected, actual)
+		}
+	}
+}
+
+func TestServiceReplace(t *testing.T) {
+	en, ru := buildSpellChecker(t), buildSpellChecker(t)
+
+	service := NewService()
+	service.AddSpellChecker("en", alphabet.CreateAlphabet([]string{"english"}), en)
+	service.AddSpellChecker("ru", alphabet.CreateAlphabet([]string{"russian"}), ru)
+
+	next := NewService()
+	next.AddSpellChecker("ru", alphabet.CreateAlphabet([]string{"russian"}), en)
+	service.Replace(next)
+
+	if languages := service.GetLanguages(); len(languages) != 1 || languages[0] != "ru" {
+		t.Errorf("Test fail, expected [ru], got %v", languages)
+	}
+
+	if _, err := service.GetSpellChecker("en", "green eggs"); err != ErrLanguageNotFound {
+		t.Errorf("Test fail, expected %v, got %v", ErrLanguageNotFound, err)
+	}
+
+	if actual, _ := service.GetSpellChecker("", "green eggs"); actual != en {
+		t.Errorf("Test fail, expected the spellchecker of the replacing service")
+	}
+
+	// the replacing service stays independent
+	next.AddSpellChecker("de", alphabet.CreateAlphabet([]string{"german"}), ru)
+
+	if _, err := service.GetSpellChecker("de", ""); err != ErrLanguageNotFound {
+		t.Errorf("Test fail, expected %v, got %v", ErrLanguageNotFound, err)
+	}
+}
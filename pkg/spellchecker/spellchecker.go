@@ -2,6 +2,10 @@
 package spellchecker
 
 import (
+	"math"
+	"sort"
+	"strings"
+
 	"github.com/suggest-go/suggest/pkg/analysis"
 	"github.com/suggest-go/suggest/pkg/dictionary"
 	"github.com/suggest-go/suggest/pkg/lm"
@@ -11,10 +15,16 @@ import (
 
 // SpellChecker describe me!
 type SpellChecker struct {
-	index     suggest.NGramIndex
-	model     lm.LanguageModel
-	tokenizer analysis.Tokenizer
-	dict      dictionary.Dictionary
+	index       suggest.NGramIndex
+	model       lm.LanguageModel
+	tokenizer   analysis.Tokenizer
+	normalizer  analysis.Normalizer
+	dict        dictionary.Dictionary
+	errorModel  ErrorModel
+	errorWeight float64
+	// confidenceThreshold is the confidence above which the original token is kept
+	confidenceThreshold float64
+	limits              Limits
 }
 
 // New creates a new instance of spellchecker
@@ -25,32 +35,62 @@ func New(
 	dict dictionary.Dictionary,
 ) *SpellChecker {
 	return &SpellChecker{
-		index:     index,
-		model:     model,
-		tokenizer: tokenizer,
-		dict:      dict,
+		index:               index,
+		model:               model,
+		tokenizer:           tokenizer,
+		normalizer:          analysis.NewIdentityNormalizer(),
+		dict:                dict,
+		confidenceThreshold: DefaultConfidenceThreshold,
+		limits:              DefaultLimits(),
 	}
 }
 
-// Predict predicts the next word of the sentence
-func (s *SpellChecker) Predict(query string, topK int, similarity float64) ([]string, error) {
+// SetNormalizer sets the normalizer applied by the tokenizer, it maps the tokens back to
+// the characters of a query
+func (s *SpellChecker) SetNormalizer(normalizer analysis.Normalizer) {
+	s.normalizer = normalizer
+}
+
+// SetErrorModel sets the error model of the spellchecker. The score of a candidate
+// becomes the sum of the lm score and the error model score multiplied by the weight
+func (s *SpellChecker) SetErrorModel(errorModel ErrorModel, weight float64) {
+	s.errorModel = errorModel
+	s.errorWeight = weight
+}
+
+// Predict predicts the next word of the sentence. The candidates are completions of the last
+// word, its fuzzy matches and its splits into vocabulary words. The result tells whether the
+// last word is spelled correctly and should be kept as is
+func (s *SpellChecker) Predict(query string, topK int, similarity float64) (PredictionResult, error) {
+	if err := s.checkLimits(query, topK); err != nil {
+		return PredictionResult{}, err
+	}
+
 	tokens := s.tokenizer.Tokenize(query)
 
 	if len(tokens) == 0 {
-		return []string{}, nil
+		return PredictionResult{Candidates: []Prediction{}}, nil
 	}
 
 	word, seq := tokens[len(tokens)-1], tokens[:len(tokens)-1]
+	span := tokenSpans(query, tokens, s.normalizer)[len(tokens)-1]
 	collectorManager, err := s.createCollectorManager(seq, topK)
 
 	if err != nil {
-		return nil, err
+		return PredictionResult{}, err
 	}
 
 	candidates, err := s.index.Autocomplete(word, collectorManager)
 
 	if err != nil {
-		return nil, err
+		return PredictionResult{}, err
+	}
+
+	prefixCount := len(candidates)
+	similarities := make(map[lm.WordID]float64, topK)
+
+	for _, c := range candidates {
+		similarities[c.Key] = 1
 	}
 
 	if len(candidates) < topK {
@@ -62,39 +102,203 @@ func (s *SpellChecker) Predict(query string, topK int, similarity float64) ([]st
 		)
 
 		if err != nil {
-			return nil, err
+			return PredictionResult{}, err
 		}
 
 		fuzzyCandidates, err := s.index.Suggest(config)
 
 		if err != nil {
-			return nil, err
+			return PredictionResult{}, err
+		}
+
+		for _, c := range fuzzyCandidates {
+			if _, ok := similarities[c.Key]; !ok {
+				similarities[c.Key] = c.Score
+			}
 		}
 
 		candidates = merge(candidates, fuzzyCandidates)
 	}
 
+	// remember the sources before the candidates are reordered
+	sources := make(map[lm.WordID]Source, len(candidates))
+
+	for i, c := range candidates {
+		if i < prefixCount {
+			sources[c.Key] = PrefixSource
+		} else {
+			sources[c.Key] = FuzzySource
+		}
+	}
+
 	scorer, ok := collectorManager.scorer.(*lmScorer)
 
-	if len(seq) > 0 && ok {
+	if s.errorModel != nil {
+		if err := s.sortWithErrorModel(word, scorer, candidates, prefixCount); err != nil {
+			return PredictionResult{}, err
+		}
+	} else if len(seq) > 0 && ok {
 		sortCandidates(scorer, candidates)
 	}
 
-	result := make([]string, 0, len(candidates))
+	seqIds, err := lm.MapIntoListOfWordIDs(s.model, seq)
+
+	if err != nil {
+		return PredictionResult{}, err
+	}
+
+	splits, err := s.predictSplits(seqIds, word)
+
+	if err != nil {
+		return PredictionResult{}, err
+	}
+
+	result := make([]Prediction, 0, len(splits)+len(candidates))
+
+	for _, split := range splits {
+		lmScore := s.conditionalScore(seqIds, split.ids)
+		result = append(result, Prediction{
+			Value:      strings.Join(split.values, " "),
+			Original:   word,
+			Source:     SplitSource,
+			LMScore:    lmScore,
+			Similarity: 1,
+			Span:       span,
+		})
+	}
 
 	for _, c := range candidates {
 		val, err := s.dict.Get(c.Key)
 
+		if err != nil {
+			return PredictionResult{}, err
+		}
+
+		lmScore := s.conditionalScore(seqIds, []lm.WordID{c.Key})
+		result = append(result, Prediction{
+			Value:      val,
+			Original:   word,
+			Source:     sources[c.Key],
+			LMScore:    lmScore,
+			Similarity: similarities[c.Key],
+			Span:       span,
+		})
+	}
+
+	if len(result) > topK {
+		result = result[:topK]
+	}
+
+	confidence, err := s.confidence(seqIds, word, result)
+
+	if err != nil {
+		return PredictionResult{}, err
+	}
+
+	return PredictionResult{
+		Original:     word,
+		KeepOriginal: confidence >= s.confidenceThreshold,
+		Confidence:   confidence,
+		Candidates:   result,
+	}, nil
+}
+
+// predictSplits returns the splits of the given word into vocabulary words.
+// A word of the vocabulary is never split, otherwise its splits are the most
+// probable corrections, as all their parts are known
+func (s *SpellChecker) predictSplits(seq []lm.WordID, word string) ([]segmentation, error) {
+	id, err := s.model.GetWordID(word)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if id != lm.UnknownWordID {
+		return nil, nil
+	}
+
+	return s.splitCandidates(seq, word)
+}
+
+// Next returns topK the most probable words that follow after the given query
+func (s *SpellChecker) Next(query string, topK int) ([]suggest.ResultItem, error) {
+	if err := s.checkLimits(query, topK); err != nil {
+		return nil, err
+	}
+
+	seq, err := lm.MapIntoListOfWordIDs(s.model, s.tokenizer.Tokenize(query))
+
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.model.TopNext(seq, topK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]suggest.ResultItem, 0, len(candidates))
+
+	for _, c := range candidates {
+		val, err := s.dict.Get(c.WordID)
+
 		if err != nil {
 			return nil, err
 		}
 
-		result = append(result, val)
+		result = append(result, suggest.ResultItem{
+			Score: c.Score,
+			Value: val,
+		})
 	}
 
 	return result, nil
 }
 
+// sortWithErrorModel sorts the candidates by the sum of the lm score and the error model score.
+// The first prefixCount candidates are completions of the word, so only their prefixes are compared with it
+func (s *SpellChecker) sortWithErrorModel(word string, scorer *lmScorer, candidates []suggest.Candidate, prefixCount int) error {
+	scores := make(map[lm.WordID]float64, len(candidates))
+	wordLen := len([]rune(word))
+
+	for i, c := range candidates {
+		value, err := s.dict.Get(c.Key)
+
+		if err != nil {
+			return err
+		}
+
+		if chars := []rune(value); i < prefixCount && len(chars) > wordLen {
+			value = string(chars[:wordLen])
+		}
+
+		score := s.errorScore(word, value, 1)
+
+		if scorer != nil {
+			score += scorer.score(c.Key)
+		}
+
+		scores[c.Key] = score
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scores[candidates[i].Key] > scores[candidates[j].Key]
+	})
+
+	return nil
+}
+
+// errorScore returns the error model score of the candidate for the given token.
+// If the error model is not set, the score is based on the similarity of the candidate
+func (s *SpellChecker) errorScore(token, candidate string, similarity float64) float64 {
+	if s.errorModel == nil {
+		return math.Log(similarity)
+	}
+
+	return s.errorWeight * s.errorModel.Score(token, candidate)
+}
+
 // createScorer creates scorer for the given sentence
 func (s *SpellChecker) createCollectorManager(seq []string, topK int) (*lmCollectorManager, error) {
 	seqIds, err := lm.MapIntoListOfWordIDs(s.model, seq)
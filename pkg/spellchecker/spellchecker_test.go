@@ -0,0 +1,252 @@
+package spellchecker
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/lm"
+	"github.com/suggest-go/suggest/pkg/store"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+func TestCorrect(t *testing.T) {
+	spellchecker := buildSpellChecker(t)
+
+	cases := []struct {
+		sentence string
+		expected []string
+	}{
+		{"I am Sam", []string{"i", "am", "sam"}},
+		{"I an sem", []string{"i", "am", "sam"}},
+		{"grean egs and hem", []string{"green", "eggs", "and", "ham"}},
+		{"i dont", []string{"i", "do"}},
+		{"iam sam", []string{"i", "am", "sam"}},
+		{"green eggsand ham", []string{"green", "eggs", "and", "ham"}},
+		{"i do notlike gre en eggs", []string{"i", "do", "not", "like", "green", "eggs"}},
+		{"s am i am", []string{"sam", "i", "am"}},
+	}
+
+	for _, c := range cases {
+		corrections, err := spellchecker.Correct(c.sentence, 3, 0.3)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if len(corrections) == 0 {
+			t.Errorf("Test fail, for %s expected %v, got nothing", c.sentence, c.expected)
+			continue
+		}
+
+		if actual := corrections[0].Tokens; !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Test fail, for %s expected %v, got %v", c.sentence, c.expected, actual)
+		}
+
+		for i := 1; i < len(corrections); i++ {
+			if corrections[i-1].Score < corrections[i].Score {
+				t.Errorf("Test fail, corrections of %s are not sorted by score", c.sentence)
+			}
+		}
+	}
+}
+
+func TestCorrectScore(t *testing.T) {
+	spellchecker := buildSpellChecker(t)
+
+	for _, sentence := range []string{"i am sam", "i do not like green eggs and ham"} {
+		corrections, err := spellchecker.Correct(sentence, 1, 0.3)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		tokens := strings.Fields(sentence)
+
+		if len(corrections) != 1 || !reflect.DeepEqual(corrections[0].Tokens, tokens) {
+			t.Fatalf("Test fail, for %s expected the sentence itself, got %v", sentence, corrections)
+		}
+
+		ids, err := lm.MapIntoListOfWordIDs(spellchecker.model, tokens)
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// the words are scored one by one after the prefix, the end of the sentence is scored once
+		expected := spellchecker.conditionalScore(nil, ids) + spellchecker.model.ScoreEnd(ids)
+
+		for _, token := range tokens {
+			expected += spellchecker.errorScore(token, token, 1)
+		}
+
+		if actual := corrections[0].Score; math.Abs(actual-expected) > 1e-9 {
+			t.Errorf("Test fail, for %s expected %v, got %v", sentence, expected, actual)
+		}
+	}
+}
+
+func TestPredict(t *testing.T) {
+	spellchecker := buildSpellChecker(t)
+
+	cases := []struct {
+		query    string
+		expected Prediction
+	}{
+		{"I do not li", Prediction{Value: "like", Original: "li", Source: PrefixSource, Similarity: 1, Span: Span{9, 11}}},
+		{"I do  NOT lke", Prediction{Value: "like", Original: "lke", Source: FuzzySource, Span: Span{10, 13}}},
+	}
+
+	for _, c := range cases {
+		result, err := spellchecker.Predict(c.query, 5, 0.3)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		actual := result.Candidates
+
+		if len(actual) == 0 {
+			t.Errorf("Test fail, for %s expected %v, got nothing", c.query, c.expected)
+			continue
+		}
+
+		first := actual[0]
+
+		if first.Value != c.expected.Value || first.Original != c.expected.Original ||
+			first.Source != c.expected.Source || first.Span != c.expected.Span {
+			t.Errorf("Test fail, for %s expected %v, got %v", c.query, c.expected, first)
+		}
+
+		if c.expected.Source == PrefixSource && first.Similarity != 1 {
+			t.Errorf("Test fail, expected the similarity of a completion to be 1, got %v", first.Similarity)
+		}
+
+		if first.Similarity <= 0 || first.Similarity > 1 {
+			t.Errorf("Test fail, expected the similarity in (0, 1], got %v", first.Similarity)
+		}
+
+		// "i do not like" is in the corpus
+		if first.LMScore <= lm.UnknownWordScore || first.LMScore > 0 {
+			t.Errorf("Test fail, expected a known lm log probability, got %v", first.LMScore)
+		}
+	}
+}
+
+func TestCorrectReplacements(t *testing.T) {
+	spellchecker := buildSpellChecker(t)
+
+	cases := []struct {
+		sentence string
+		expected []TokenCorrection
+	}{
+		{"I am Sam", []TokenCorrection{}},
+		{"I  an Sem", []TokenCorrection{
+			{Original: "an", Value: "am", Source: FuzzySource, Span: Span{3, 5}},
+			{Original: "sem", Value: "sam", Source: FuzzySource, Span: Span{6, 9}},
+		}},
+		{"green eggsand ham", []TokenCorrection{
+			{Original: "eggsand", Value: "eggs and", Source: SplitSource, Span: Span{6, 13}},
+		}},
+		{"gre en eggs", []TokenCorrection{
+			{Original: "gre en", Value: "green", Source: JoinSource, Span: Span{0, 6}},
+		}},
+	}
+
+	for _, c := range cases {
+		corrections, err := spellchecker.Correct(c.sentence, 1, 0.3)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if len(corrections) != 1 {
+			t.Errorf("Test fail, for %s expected 1 correction, got %v", c.sentence, corrections)
+			continue
+		}
+
+		if actual := corrections[0].Corrections; !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Test fail, for %s expected %v, got %v", c.sentence, c.expected, actual)
+		}
+	}
+}
+
+func TestPredictSplit(t *testing.T) {
+	spellchecker := buildSpellChecker(t)
+
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{"i do notlike", "not like"},
+		{"eggsand", "eggs and"},
+		{"iam", "i am"},
+	}
+
+	for _, c := range cases {
+		result, err := spellchecker.Predict(c.query, 5, 0.3)
+
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		actual := result.Candidates
+
+		if len(actual) == 0 || actual[0].Value != c.expected || actual[0].Source != SplitSource {
+			t.Errorf("Test fail, for %s expected %v, got %v", c.query, c.expected, actual)
+		}
+
+		if len(actual) > 5 {
+			t.Errorf("Test fail, expected at most 5 candidates, got %v", actual)
+		}
+	}
+}
+
+// buildSpellChecker creates a spellchecker for the test language model
+func buildSpellChecker(t *testing.T) *SpellChecker {
+	config, err := lm.ReadConfig("../lm/testdata/config-example.json")
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	directory, err := store.NewFSDirectory(config.GetOutputPath())
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	languageModel, err := lm.RetrieveLMFromBinary(directory, config)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	dict, err := dictionary.OpenCDBDictionary(config.GetDictionaryPath())
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	builder, err := suggest.NewRAMBuilder(dict, suggest.IndexDescription{
+		Name:      "words",
+		NGramSize: 2,
+		Wrap:      [2]string{"^", "$"},
+		Pad:       "$",
+		Alphabet:  []string{"english", "$^"},
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	index, err := builder.Build()
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return New(index, languageModel, lm.NewTokenizer(config.GetWordsAlphabet()), dict)
+}
@@ -0,0 +1,89 @@
+package suggest
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchQuery is a query of the batch topK approximate string search
+type BatchQuery struct {
+	// Dict is the name of the dictionary to search in
+	Dict string
+	// Config is the search config of the query
+	Config SearchConfig
+}
+
+// AutocompleteQuery is a query of the batch autocomplete
+type AutocompleteQuery struct {
+	// Dict is the name of the dictionary to search in
+	Dict string
+	// Query is the prefix to complete
+	Query string
+	// Limit is the max number of candidates
+	Limit int
+}
+
+// BatchResult is the result of a query of the batch
+type BatchResult struct {
+	// Items is the list of candidates of the query
+	Items []ResultItem
+	// Err is the error occurred during the query processing
+	Err error
+}
+
+// SuggestBatch performs Suggest for each of the given queries concurrently by at most workers
+// goroutines. The results are returned in the order of the queries. If workers is not positive,
+// the number of CPUs is used
+func (s *Service) SuggestBatch(queries []BatchQuery, workers int) []BatchResult {
+	return runBatch(len(queries), workers, func(i int) BatchResult {
+		items, err := s.Suggest(queries[i].Dict, queries[i].Config)
+
+		return BatchResult{Items: items, Err: err}
+	})
+}
+
+// AutocompleteBatch performs Autocomplete for each of the given queries concurrently by at most
+// workers goroutines. The results are returned in the order of the queries. If workers is not
+// positive, the number of CPUs is used
+func (s *Service) AutocompleteBatch(queries []AutocompleteQuery, workers int) []BatchResult {
+	return runBatch(len(queries), workers, func(i int) BatchResult {
+		items, err := s.Autocomplete(queries[i].Dict, queries[i].Query, queries[i].Limit)
+
+		return BatchResult{Items: items, Err: err}
+	})
+}
+
+// runBatch calls fn for each index in [0, n) by the bounded pool of workers
+func runBatch(n, workers int, fn func(i int) BatchResult) []BatchResult {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	if workers > n {
+		workers = n
+	}
+
+	results := make([]BatchResult, n)
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				results[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,88 @@
+package suggest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestSuggestBatch(t *testing.T) {
+	service := buildService(t, nil)
+	wordsList := []string{"Nissan March", "Honda Fitt", "Wolfsvagen", "Tayota Corolla", "Micra Nissan"}
+	expectedValues := [][]string{
+		{"NISSAN MARCH"},
+		{"HONDA FIT"},
+		{},
+		{"TOYOTA COROLLA"},
+		{"NISSAN MICRA"},
+	}
+
+	queries := make([]BatchQuery, 0, len(wordsList))
+
+	for _, word := range wordsList {
+		searchConf, err := NewSearchConfig(word, 5, metric.CosineMetric(), 0.7)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		queries = append(queries, BatchQuery{Dict: "cars", Config: searchConf})
+	}
+
+	queries = append(queries, BatchQuery{Dict: "unknown", Config: queries[0].Config})
+
+	for _, workers := range []int{0, 1, 3, 100} {
+		results := service.SuggestBatch(queries, workers)
+
+		if len(results) != len(queries) {
+			t.Errorf("Test fail, expected %v, got %v", len(queries), len(results))
+		}
+
+		for i, expected := range expectedValues {
+			if results[i].Err != nil {
+				t.Errorf("Unexpected error %v", results[i].Err)
+			}
+
+			actual := make([]string, 0, len(results[i].Items))
+
+			for _, item := range results[i].Items {
+				actual = append(actual, item.Value)
+			}
+
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("Test fail, expected %v, got %v", expected, actual)
+			}
+		}
+
+		if err := results[len(results)-1].Err; err != ErrDictionaryNotFound {
+			t.Errorf("Test fail, expected %v, got %v", ErrDictionaryNotFound, err)
+		}
+	}
+}
+
+func TestAutocompleteBatch(t *testing.T) {
+	service := buildService(t, nil)
+	queries := []AutocompleteQuery{
+		{Dict: "cars", Query: "nissan mar", Limit: 5},
+		{Dict: "cars", Query: "xyz", Limit: 5},
+	}
+
+	results := service.AutocompleteBatch(queries, 2)
+
+	for i, query := range queries {
+		expected, err := service.Autocomplete(query.Dict, query.Query, query.Limit)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if results[i].Err != nil || !reflect.DeepEqual(results[i].Items, expected) {
+			t.Errorf("Test fail, expected %v, got %v", expected, results[i].Items)
+		}
+	}
+
+	if results := service.AutocompleteBatch(nil, 2); len(results) != 0 {
+		t.Errorf("Test fail, expected empty results, got %v", results)
+	}
+}
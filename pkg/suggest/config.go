@@ -1,12 +1,14 @@
 package suggest
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 
+	"github.com/suggest-go/suggest/pkg/alphabet"
 	"github.com/suggest-go/suggest/pkg/analysis"
 	"github.com/suggest-go/suggest/pkg/index"
 )
@@ -31,7 +33,36 @@ type IndexDescription struct {
 	Alphabet   []string  `json:"alphabet"`
 	Pad        string    `json:"pad"`
 	Wrap       [2]string `json:"wrap"`
-	basePath   string
+	// DefaultMetric is the metric of a query that doesn't declare it, Cosine by default
+	DefaultMetric string `json:"defaultMetric"`
+	// DefaultSimilarity is the similarity of a query that doesn't declare it, 0.5 by default
+	DefaultSimilarity float64 `json:"defaultSimilarity"`
+	// DefaultTopK is the topK of a query that doesn't declare it, 5 by default
+	DefaultTopK int `json:"defaultTopK"`
+	// MaxTopK is the max allowed topK of a query, 1000 by default
+	MaxTopK int `json:"maxTopK"`
+	// MaxQueryLength is the max allowed number of characters of a query, 1000 by default
+	MaxQueryLength int `json:"maxQueryLength"`
+	// Normalization is applied to the dictionary items and to the queries, only the text is lowercased by default
+	Normalization *analysis.NormalizationConfig `json:"normalization"`
+	// QueryExpansion lists the alternative spellings of a query that are searched too,
+	// e.g. "keyboardLayout" and "transliteration"
+	QueryExpansion []string `json:"queryExpansion"`
+	// Analyzer replaces the n-gram pipeline of nGramSize, alphabet, pad and wrap with the declared chain
+	Analyzer *analysis.AnalyzerConfig `json:"analyzer"`
+	// AutocompleteAnalyzer is the chain of the autocomplete queries, Analyzer by default
+	AutocompleteAnalyzer *analysis.AnalyzerConfig `json:"autocompleteAnalyzer"`
+	// Synonyms are the alternative forms of the query phrases that are searched too
+	Synonyms *SynonymsConfig `json:"synonyms"`
+	basePath string
+}
+
+// SynonymsConfig describes the synonyms of the queries
+type SynonymsConfig struct {
+	// File is the path of the synonyms file, see analysis.ReadSynonyms for the format
+	File string `json:"file"`
+	// Penalty lowers the scores of the synonym matches, the score is multiplied by 1 - penalty
+	Penalty float64 `json:"penalty"`
 }
 
 // GetDictionaryFile returns a path to a dictionary file from the configuration
@@ -65,8 +96,111 @@ func (d *IndexDescription) GetWriterConfig() index.WriterConfig {
 	}
 }
 
+// GetNormalizer returns the normalizer of the dictionary items and the queries
+func (d *IndexDescription) GetNormalizer() (analysis.Normalizer, error) {
+	if d.Normalization == nil {
+		return analysis.NewIdentityNormalizer(), nil
+	}
+
+	return analysis.NewNormalizer(*d.Normalization)
+}
+
+// GetAnalyzer returns the declared analyzer of the dictionary items and the suggest queries,
+// nil if the description uses the n-gram pipeline
+func (d *IndexDescription) GetAnalyzer() (analysis.Tokenizer, error) {
+	if d.Analyzer == nil {
+		return nil, nil
+	}
+
+	return analysis.NewAnalyzer(*d.Analyzer)
+}
+
+// GetAutocompleteAnalyzer returns the declared analyzer of the autocomplete queries,
+// nil if the description uses the n-gram pipeline
+func (d *IndexDescription) GetAutocompleteAnalyzer() (analysis.Tokenizer, error) {
+	if d.AutocompleteAnalyzer == nil {
+		return d.GetAnalyzer()
+	}
+
+	return analysis.NewAnalyzer(*d.AutocompleteAnalyzer)
+}
+
+// validateAnalysis checks that the alphabet, the normalization and the analyzers of the description can be created
+func (d *IndexDescription) validateAnalysis() error {
+	if _, err := alphabet.ParseAlphabet(d.Alphabet); err != nil {
+		return fmt.Errorf("invalid alphabet: %v", err)
+	}
+
+	if _, err := d.GetNormalizer(); err != nil {
+		return fmt.Errorf("invalid normalization: %v", err)
+	}
+
+	if _, err := d.GetAnalyzer(); err != nil {
+		return fmt.Errorf("invalid analyzer: %v", err)
+	}
+
+	if _, err := d.GetAutocompleteAnalyzer(); err != nil {
+		return fmt.Errorf("invalid autocomplete analyzer: %v", err)
+	}
+
+	return nil
+}
+
+// GetQueryExpander returns the expander of the queries, nil if there is no expansion
+func (d *IndexDescription) GetQueryExpander() (analysis.QueryExpander, error) {
+	if len(d.QueryExpansion) == 0 {
+		return nil, nil
+	}
+
+	return analysis.NewQueryExpander(d.QueryExpansion)
+}
+
+// GetSynonymsFile returns a path to the synonyms file of the index description
+func (d *IndexDescription) GetSynonymsFile() string {
+	if !path.IsAbs(d.Synonyms.File) {
+		return fmt.Sprintf("%s/%s", d.basePath, d.Synonyms.File)
+	}
+
+	return d.Synonyms.File
+}
+
+// getQueryExpansions returns the expanders of the queries with the weights of their candidates
+func (d *IndexDescription) getQueryExpansions() ([]queryExpansion, error) {
+	expansions := []queryExpansion{}
+	expander, err := d.GetQueryExpander()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if expander != nil {
+		expansions = append(expansions, queryExpansion{expander: expander, weight: 1})
+	}
+
+	if d.Synonyms == nil {
+		return expansions, nil
+	}
+
+	if d.Synonyms.Penalty < 0 || d.Synonyms.Penalty >= 1 {
+		return nil, fmt.Errorf("synonyms penalty should be in range [0, 1), got %v", d.Synonyms.Penalty)
+	}
+
+	synonyms, err := analysis.ReadSynonyms(d.GetSynonymsFile())
+
+	if err != nil {
+		return nil, err
+	}
+
+	expansions = append(expansions, queryExpansion{
+		expander: analysis.NewSynonymExpander(synonyms),
+		weight:   1 - d.Synonyms.Penalty,
+	})
+
+	return expansions, nil
+}
+
 // GetIndexTokenizer returns a tokenizer for indexing
-func (d *IndexDescription) GetIndexTokenizer() analysis.Tokenizer {
+func (d *IndexDescription) GetIndexTokenizer() (analysis.Tokenizer, error) {
 	return NewSuggestTokenizer(*d)
 }
 
@@ -80,7 +214,9 @@ func (d *IndexDescription) getDocumentListFile() string {
 	return fmt.Sprintf("%s.dl", d.Name)
 }
 
-// ReadConfigs reads and returns a list of IndexDescription from the given reader
+// ReadConfigs reads and returns a list of IndexDescription from the given reader. The config
+// file is either the list of the descriptions or an object with the list in the "dictionaries"
+// field, the other fields of the object (e.g. the server settings) are ignored
 func ReadConfigs(configPath string) ([]IndexDescription, error) {
 	configFile, err := os.Open(configPath)
 
@@ -97,7 +233,17 @@ func ReadConfigs(configPath string) ([]IndexDescription, error) {
 		return nil, err
 	}
 
-	if err := json.Unmarshal(data, &configs); err != nil {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var config struct {
+			Dictionaries []IndexDescription `json:"dictionaries"`
+		}
+
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, err
+		}
+
+		configs = config.Dictionaries
+	} else if err := json.Unmarshal(data, &configs); err != nil {
 		return nil, err
 	}
 
@@ -3,6 +3,7 @@ package suggest
 import (
 	"fmt"
 
+	"github.com/suggest-go/suggest/pkg/analysis"
 	"github.com/suggest-go/suggest/pkg/dictionary"
 	"github.com/suggest-go/suggest/pkg/merger"
 	"github.com/suggest-go/suggest/pkg/store"
@@ -18,16 +19,27 @@ type Builder interface {
 
 // builderImpl implements Builder interface
 type builderImpl struct {
-	indexReader *index.Reader
-	description IndexDescription
+	indexReader           *index.Reader
+	suggestTokenizer      analysis.Tokenizer
+	autocompleteTokenizer analysis.Tokenizer
 }
 
 // NewRAMBuilder creates a search index by using the given dictionary and the index description
 // in a RAMDriver directory
 func NewRAMBuilder(dict dictionary.Dictionary, description IndexDescription) (Builder, error) {
+	if err := description.validateAnalysis(); err != nil {
+		return nil, fmt.Errorf("invalid analysis of %s: %v", description.Name, err)
+	}
+
+	tokenizer, err := description.GetIndexTokenizer()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an index tokenizer: %v", err)
+	}
+
 	directory := store.NewRAMDirectory()
 
-	if err := Index(directory, dict, description.GetWriterConfig(), description.GetIndexTokenizer()); err != nil {
+	if err := Index(directory, dict, description.GetWriterConfig(), tokenizer); err != nil {
 		return nil, fmt.Errorf("failed to create a ram search index: %v", err)
 	}
 
@@ -47,12 +59,29 @@ func NewFSBuilder(description IndexDescription) (Builder, error) {
 
 // NewBuilder works with already indexed data
 func NewBuilder(directory store.Directory, description IndexDescription) (Builder, error) {
+	if err := description.validateAnalysis(); err != nil {
+		return nil, fmt.Errorf("invalid analysis of %s: %v", description.Name, err)
+	}
+
+	suggestTokenizer, err := NewSuggestTokenizer(description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a suggest tokenizer of %s: %v", description.Name, err)
+	}
+
+	autocompleteTokenizer, err := NewAutocompleteTokenizer(description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an autocomplete tokenizer of %s: %v", description.Name, err)
+	}
+
 	return &builderImpl{
 		indexReader: index.NewIndexReader(
 			directory,
 			description.GetWriterConfig(),
 		),
-		description: description,
+		suggestTokenizer:      suggestTokenizer,
+		autocompleteTokenizer: autocompleteTokenizer,
 	}, nil
 }
 
@@ -67,13 +96,13 @@ func (b *builderImpl) Build() (NGramIndex, error) {
 	suggester := NewSuggester(
 		invertedIndices,
 		index.NewSearcher(merger.CPMerge()),
-		NewSuggestTokenizer(b.description),
+		b.suggestTokenizer,
 	)
 
 	autocomplete := NewAutocomplete(
 		invertedIndices,
 		index.NewSearcher(merger.CPMerge()),
-		NewAutocompleteTokenizer(b.description),
+		b.autocompleteTokenizer,
 	)
 
 	return NewNGramIndex(
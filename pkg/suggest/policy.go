@@ -0,0 +1,151 @@
+package suggest
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+const (
+	// DefaultTopK is the topK of a query that doesn't declare it
+	DefaultTopK = 5
+	// DefaultSimilarity is the similarity of a query that doesn't declare it
+	DefaultSimilarity = 0.5
+	// DefaultMetric is the metric of a query that doesn't declare it
+	DefaultMetric = metric.Cosine
+	// DefaultMaxTopK is the max topK of a query to a dictionary that doesn't declare it
+	DefaultMaxTopK = 1000
+	// DefaultMaxQueryLength is the max number of characters of a query to a dictionary that doesn't declare it
+	DefaultMaxQueryLength = 1000
+)
+
+var (
+	// ErrTopKLimit tells that the topK of a query exceeds the limit of the dictionary
+	ErrTopKLimit = errors.New("topK exceeds the limit of the dictionary")
+	// ErrQueryLengthLimit tells that a query is longer than the limit of the dictionary
+	ErrQueryLengthLimit = errors.New("query exceeds the max length of the dictionary")
+)
+
+// QueryPolicy holds the defaults and the limits of the queries to a dictionary
+type QueryPolicy struct {
+	// Metric is the name of the default metric
+	Metric string
+	// Similarity is the default similarity
+	Similarity float64
+	// TopK is the default topK
+	TopK int
+	// MaxTopK is the max allowed topK
+	MaxTopK int
+	// MaxQueryLength is the max allowed number of characters of a query
+	MaxQueryLength int
+}
+
+// QueryOptions are the optional parameters of a search query, the zero values
+// are replaced with the defaults of the dictionary
+type QueryOptions struct {
+	TopK       int
+	Metric     string
+	Similarity float64
+}
+
+// defaultQueryPolicy returns the policy of a dictionary that doesn't declare its own one
+func defaultQueryPolicy() QueryPolicy {
+	return QueryPolicy{
+		Metric:         DefaultMetric,
+		Similarity:     DefaultSimilarity,
+		TopK:           DefaultTopK,
+		MaxTopK:        DefaultMaxTopK,
+		MaxQueryLength: DefaultMaxQueryLength,
+	}
+}
+
+// GetQueryPolicy returns the query policy of the dictionary, the omitted values are set to the defaults
+func (d *IndexDescription) GetQueryPolicy() (QueryPolicy, error) {
+	policy := defaultQueryPolicy()
+
+	if d.DefaultMetric != "" {
+		policy.Metric = d.DefaultMetric
+	}
+
+	if d.DefaultSimilarity != 0 {
+		policy.Similarity = d.DefaultSimilarity
+	}
+
+	if d.DefaultTopK != 0 {
+		policy.TopK = d.DefaultTopK
+	}
+
+	if d.MaxTopK != 0 {
+		policy.MaxTopK = d.MaxTopK
+	}
+
+	if d.MaxQueryLength != 0 {
+		policy.MaxQueryLength = d.MaxQueryLength
+	}
+
+	if _, err := metric.GetMetric(policy.Metric); err != nil {
+		return QueryPolicy{}, fmt.Errorf("metric %s of %s is not found", policy.Metric, d.Name)
+	}
+
+	if policy.Similarity <= 0 || policy.Similarity > 1 {
+		return QueryPolicy{}, fmt.Errorf("default similarity of %s should be in (0.0, 1.0]", d.Name)
+	}
+
+	if policy.TopK <= 0 || policy.MaxTopK <= 0 || policy.TopK > policy.MaxTopK {
+		return QueryPolicy{}, fmt.Errorf("default topK of %s should be in [1, maxTopK]", d.Name)
+	}
+
+	if policy.MaxQueryLength <= 0 {
+		return QueryPolicy{}, fmt.Errorf("max query length of %s should be positive", d.Name)
+	}
+
+	return policy, nil
+}
+
+// searchConfig returns the search config of the query with the omitted options set to the defaults
+func (p QueryPolicy) searchConfig(query string, options QueryOptions) (SearchConfig, error) {
+	if options.TopK == 0 {
+		options.TopK = p.TopK
+	}
+
+	if options.Metric == "" {
+		options.Metric = p.Metric
+	}
+
+	if options.Similarity == 0 {
+		options.Similarity = p.Similarity
+	}
+
+	m, err := metric.GetMetric(options.Metric)
+
+	if err != nil {
+		return SearchConfig{}, err
+	}
+
+	config, err := NewSearchConfig(query, options.TopK, m, options.Similarity)
+
+	if err != nil {
+		return SearchConfig{}, err
+	}
+
+	if err := p.check(query, options.TopK); err != nil {
+		return SearchConfig{}, err
+	}
+
+	return config, nil
+}
+
+// check returns an error if the query or the topK exceeds the limits
+func (p QueryPolicy) check(query string, topK int) error {
+	if topK > p.MaxTopK {
+		return ErrTopKLimit
+	}
+
+	if utf8.RuneCountInString(query) > p.MaxQueryLength {
+		return ErrQueryLengthLimit
+	}
+
+	return nil
+}
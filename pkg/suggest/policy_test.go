@@ -0,0 +1,117 @@
+package suggest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestGetQueryPolicy(t *testing.T) {
+	testCases := []struct {
+		description IndexDescription
+		expected    QueryPolicy
+		fail        bool
+	}{
+		{IndexDescription{}, defaultQueryPolicy(), false},
+		{
+			IndexDescription{DefaultMetric: metric.Jaccard, DefaultSimilarity: 0.7, DefaultTopK: 10, MaxTopK: 20, MaxQueryLength: 30},
+			QueryPolicy{Metric: metric.Jaccard, Similarity: 0.7, TopK: 10, MaxTopK: 20, MaxQueryLength: 30},
+			false,
+		},
+		{IndexDescription{DefaultMetric: "Unknown"}, QueryPolicy{}, true},
+		{IndexDescription{DefaultSimilarity: 1.5}, QueryPolicy{}, true},
+		{IndexDescription{DefaultTopK: 10, MaxTopK: 5}, QueryPolicy{}, true},
+		{IndexDescription{MaxQueryLength: -1}, QueryPolicy{}, true},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := testCase.description.GetQueryPolicy()
+
+		if (err != nil) != testCase.fail {
+			t.Errorf("Unexpected error %v", err)
+		}
+
+		if actual != testCase.expected {
+			t.Errorf("Test fail, expected %v, got %v", testCase.expected, actual)
+		}
+	}
+}
+
+func TestServiceNewSearchConfig(t *testing.T) {
+	service := buildService(t, configurePolicy)
+
+	testCases := []struct {
+		dict     string
+		query    string
+		options  QueryOptions
+		expected SearchConfig
+		err      error
+	}{
+		{"cars", "nissan", QueryOptions{}, SearchConfig{"nissan", 3, metric.JaccardMetric(), 0.6}, nil},
+		{"cars", "nissan", QueryOptions{TopK: 4, Metric: metric.Dice, Similarity: 0.2}, SearchConfig{"nissan", 4, metric.DiceMetric(), 0.2}, nil},
+		{"cars", "nissan", QueryOptions{TopK: 11}, SearchConfig{}, ErrTopKLimit},
+		{"cars", strings.Repeat("ы", 21), QueryOptions{}, SearchConfig{}, ErrQueryLengthLimit},
+		{"cars", "nissan", QueryOptions{Metric: "Unknown"}, SearchConfig{}, metric.ErrMetricNotFound},
+		{"unknown", "nissan", QueryOptions{}, SearchConfig{}, ErrDictionaryNotFound},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := service.NewSearchConfig(testCase.dict, testCase.query, testCase.options)
+
+		if err != testCase.err {
+			t.Errorf("Test fail, expected %v, got %v", testCase.err, err)
+		}
+
+		if !reflect.DeepEqual(actual, testCase.expected) {
+			t.Errorf("Test fail, expected %v, got %v", testCase.expected, actual)
+		}
+	}
+}
+
+func TestServiceEnforcesLimits(t *testing.T) {
+	service := buildService(t, configurePolicy)
+	searchConf, err := NewSearchConfig("nissan", 11, metric.CosineMetric(), 0.5)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Suggest("cars", searchConf); err != ErrTopKLimit {
+		t.Errorf("Test fail, expected %v, got %v", ErrTopKLimit, err)
+	}
+
+	searchConf, err = NewSearchConfig(strings.Repeat("a", 21), 5, metric.CosineMetric(), 0.5)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Suggest("cars", searchConf); err != ErrQueryLengthLimit {
+		t.Errorf("Test fail, expected %v, got %v", ErrQueryLengthLimit, err)
+	}
+
+	if _, err := service.Autocomplete("cars", "nis", 11); err != ErrTopKLimit {
+		t.Errorf("Test fail, expected %v, got %v", ErrTopKLimit, err)
+	}
+
+	result, err := service.Autocomplete("cars", "Niss", 0)
+
+	if err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	if len(result) != 3 {
+		t.Errorf("Test fail, expected %v, got %v", 3, len(result))
+	}
+}
+
+// configurePolicy sets the query defaults and limits of the policy tests
+func configurePolicy(description *IndexDescription) {
+	description.DefaultMetric = metric.Jaccard
+	description.DefaultSimilarity = 0.6
+	description.DefaultTopK = 3
+	description.MaxTopK = 10
+	description.MaxQueryLength = 20
+}
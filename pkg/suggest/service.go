@@ -2,12 +2,42 @@
 package suggest
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/suggest-go/suggest/pkg/analysis"
 	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
 )
 
+// ErrDictionaryNotFound tells that the service doesn't manage the requested dictionary
+var ErrDictionaryNotFound = errors.New("dictionary is not found")
+
+const (
+	// SuggestMethod is the name of the Suggest method passed to the RequestObserver
+	SuggestMethod = "suggest"
+	// AutocompleteMethod is the name of the Autocomplete method passed to the RequestObserver
+	AutocompleteMethod = "autocomplete"
+)
+
+// RequestObserver is notified about each request handled by the service
+type RequestObserver func(method, dict string, elapsed time.Duration, candidates int, err error)
+
+// DictionaryInfo describes the index of a dictionary served by the service
+type DictionaryInfo struct {
+	// Name is the name of the dictionary
+	Name string `json:"name"`
+	// Generation is the number of times the index of the dictionary was loaded
+	Generation uint64 `json:"generation"`
+	// LoadedAt is the time when the served index was loaded
+	LoadedAt time.Time `json:"loadedAt"`
+	// Size is the number of items of the dictionary
+	Size int `json:"size"`
+}
+
 // ResultItem represents element of top-k similar strings in dictionary for given query
 type ResultItem struct {
 	// Score is a float64 value of a candidate
@@ -21,6 +51,11 @@ type Service struct {
 	sync.RWMutex
 	indexes      map[string]NGramIndex
 	dictionaries map[string]dictionary.Dictionary
+	generations  map[string]uint64
+	loadedAt     map[string]time.Time
+	policies     map[string]QueryPolicy
+	expansions   map[string][]queryExpansion
+	observer     RequestObserver
 }
 
 // NewService creates an empty SuggestService
@@ -28,6 +63,10 @@ func NewService() *Service {
 	return &Service{
 		indexes:      make(map[string]NGramIndex),
 		dictionaries: make(map[string]dictionary.Dictionary),
+		generations:  make(map[string]uint64),
+		loadedAt:     make(map[string]time.Time),
+		policies:     make(map[string]QueryPolicy),
+		expansions:   make(map[string][]queryExpansion),
 	}
 }
 
@@ -42,6 +81,18 @@ func (s *Service) AddIndexByDescription(description IndexDescription) error {
 
 // AddRunTimeIndex adds a new RAM search index with the given description
 func (s *Service) AddRunTimeIndex(description IndexDescription) error {
+	policy, err := description.GetQueryPolicy()
+
+	if err != nil {
+		return err
+	}
+
+	expansions, err := description.getQueryExpansions()
+
+	if err != nil {
+		return err
+	}
+
 	dict, err := dictionary.OpenRAMDictionary(description.GetSourcePath())
 
 	if err != nil {
@@ -54,11 +105,23 @@ func (s *Service) AddRunTimeIndex(description IndexDescription) error {
 		return fmt.Errorf("failed to create RAMDriver builder: %v", err)
 	}
 
-	return s.AddIndex(description.Name, dict, builder)
+	return s.addIndex(description.Name, dict, builder, policy, expansions)
 }
 
 // AddOnDiscIndex adds a new DISC search index with the given description
 func (s *Service) AddOnDiscIndex(description IndexDescription) error {
+	policy, err := description.GetQueryPolicy()
+
+	if err != nil {
+		return err
+	}
+
+	expansions, err := description.getQueryExpansions()
+
+	if err != nil {
+		return err
+	}
+
 	dict, err := dictionary.OpenCDBDictionary(description.GetDictionaryFile())
 
 	if err != nil {
@@ -71,11 +134,23 @@ func (s *Service) AddOnDiscIndex(description IndexDescription) error {
 		return fmt.Errorf("failed to open FS inverted index: %v", err)
 	}
 
-	return s.AddIndex(description.Name, dict, builder)
+	return s.addIndex(description.Name, dict, builder, policy, expansions)
 }
 
-// AddIndex adds an index with the given name, dictionary and builder
+// AddIndex adds an index with the given name, dictionary and builder. The queries to the
+// index use the default query policy
 func (s *Service) AddIndex(name string, dict dictionary.Dictionary, builder Builder) error {
+	return s.addIndex(name, dict, builder, defaultQueryPolicy(), nil)
+}
+
+// addIndex adds an index with the given name, dictionary, builder, query policy and query expansions
+func (s *Service) addIndex(
+	name string,
+	dict dictionary.Dictionary,
+	builder Builder,
+	policy QueryPolicy,
+	expansions []queryExpansion,
+) error {
 	nGramIndex, err := builder.Build()
 
 	if err != nil {
@@ -85,13 +160,86 @@ func (s *Service) AddIndex(name string, dict dictionary.Dictionary, builder Buil
 	s.Lock()
 	s.indexes[name] = nGramIndex
 	s.dictionaries[name] = dict
+	s.generations[name]++
+	s.loadedAt[name] = time.Now()
+	s.policies[name] = policy
+	s.expansions[name] = expansions
 	s.Unlock()
 
 	return nil
 }
 
+// Replace atomically replaces the indexes of the service with the indexes of the given
+// service. The dictionaries that are not managed by the given service are dropped
+func (s *Service) Replace(other *Service) {
+	if s == other {
+		return
+	}
+
+	other.RLock()
+	indexes := make(map[string]NGramIndex, len(other.indexes))
+	dictionaries := make(map[string]dictionary.Dictionary, len(other.dictionaries))
+	generations := make(map[string]uint64, len(other.generations))
+	loadedAt := make(map[string]time.Time, len(other.loadedAt))
+	policies := make(map[string]QueryPolicy, len(other.policies))
+	expansions := make(map[string][]queryExpansion, len(other.expansions))
+
+	for name, index := range other.indexes {
+		indexes[name] = index
+		dictionaries[name] = other.dictionaries[name]
+		generations[name] = other.generations[name]
+		loadedAt[name] = other.loadedAt[name]
+		policies[name] = other.policies[name]
+		expansions[name] = other.expansions[name]
+	}
+
+	other.RUnlock()
+
+	s.Lock()
+
+	for name := range indexes {
+		generations[name] += s.generations[name]
+	}
+
+	s.indexes = indexes
+	s.dictionaries = dictionaries
+	s.generations = generations
+	s.loadedAt = loadedAt
+	s.policies = policies
+	s.expansions = expansions
+	s.Unlock()
+}
+
+// GetQueryPolicy returns the query policy of the given dictionary
+func (s *Service) GetQueryPolicy(dictName string) (QueryPolicy, error) {
+	s.RLock()
+	policy, ok := s.policies[dictName]
+	s.RUnlock()
+
+	if !ok {
+		return QueryPolicy{}, ErrDictionaryNotFound
+	}
+
+	return policy, nil
+}
+
+// NewSearchConfig returns the search config of the query to the given dictionary. The omitted
+// options are set to the defaults of the dictionary and the limits of the dictionary are checked
+func (s *Service) NewSearchConfig(dictName, query string, options QueryOptions) (SearchConfig, error) {
+	policy, err := s.GetQueryPolicy(dictName)
+
+	if err != nil {
+		return SearchConfig{}, err
+	}
+
+	return policy.searchConfig(query, options)
+}
+
 // GetDictionaries returns the managed list of dictionaries
 func (s *Service) GetDictionaries() []string {
+	s.RLock()
+	defer s.RUnlock()
+
 	names := make([]string, 0, len(s.dictionaries))
 
 	for name := range s.dictionaries {
@@ -101,15 +249,74 @@ func (s *Service) GetDictionaries() []string {
 	return names
 }
 
+// SetRequestObserver sets the observer that is notified about each Suggest and Autocomplete request
+func (s *Service) SetRequestObserver(observer RequestObserver) {
+	s.Lock()
+	s.observer = observer
+	s.Unlock()
+}
+
+// GetDictionarySizes returns the number of items of each managed dictionary
+func (s *Service) GetDictionarySizes() map[string]int {
+	s.RLock()
+	defer s.RUnlock()
+
+	sizes := make(map[string]int, len(s.dictionaries))
+
+	for name, dict := range s.dictionaries {
+		sizes[name] = dict.Size()
+	}
+
+	return sizes
+}
+
+// GetDictionaryInfos returns the description of each managed dictionary ordered by the name
+func (s *Service) GetDictionaryInfos() []DictionaryInfo {
+	s.RLock()
+	defer s.RUnlock()
+
+	infos := make([]DictionaryInfo, 0, len(s.dictionaries))
+
+	for name, dict := range s.dictionaries {
+		infos = append(infos, DictionaryInfo{
+			Name:       name,
+			Generation: s.generations[name],
+			LoadedAt:   s.loadedAt[name],
+			Size:       dict.Size(),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
+
 // Suggest returns Top-k approximate strings for the given query in the dict
 func (s *Service) Suggest(dictName string, config SearchConfig) ([]ResultItem, error) {
+	start := time.Now()
+	result, err := s.suggest(dictName, config)
+	s.observe(SuggestMethod, dictName, start, len(result), err)
+
+	return result, err
+}
+
+// suggest performs Top-k approximate string search
+func (s *Service) suggest(dictName string, config SearchConfig) ([]ResultItem, error) {
 	s.RLock()
 	index, okIndex := s.indexes[dictName]
 	dict, okDict := s.dictionaries[dictName]
+	policy := s.policies[dictName]
+	expansions := s.expansions[dictName]
 	s.RUnlock()
 
 	if !okDict || !okIndex {
-		return nil, fmt.Errorf("given dictionary %s is not exists", dictName)
+		return nil, ErrDictionaryNotFound
+	}
+
+	if err := policy.check(config.query, config.topK); err != nil {
+		return nil, err
 	}
 
 	candidates, err := index.Suggest(config)
@@ -118,6 +325,26 @@ func (s *Service) Suggest(dictName string, config SearchConfig) ([]ResultItem, e
 		return nil, err
 	}
 
+	if len(expansions) > 0 {
+		lists := [][]Candidate{candidates}
+
+		for _, expansion := range expansions {
+			for _, variant := range expansion.expander.Expand(config.query) {
+				variantConfig := config
+				variantConfig.query = variant
+				variantCandidates, err := index.Suggest(variantConfig)
+
+				if err != nil {
+					return nil, err
+				}
+
+				lists = append(lists, weightCandidates(variantCandidates, expansion.weight))
+			}
+		}
+
+		candidates = mergeCandidates(config.topK, lists)
+	}
+
 	l := len(candidates)
 	result := make([]ResultItem, 0, l)
 
@@ -133,15 +360,35 @@ func (s *Service) Suggest(dictName string, config SearchConfig) ([]ResultItem, e
 	return result, nil
 }
 
-// Autocomplete returns limit candidates where the query string is a prefix of each candidate
+// Autocomplete returns limit candidates where the query string is a prefix of each candidate.
+// The default topK of the dictionary is used if the limit is not positive
 func (s *Service) Autocomplete(dictName string, query string, limit int) ([]ResultItem, error) {
+	start := time.Now()
+	result, err := s.autocomplete(dictName, query, limit)
+	s.observe(AutocompleteMethod, dictName, start, len(result), err)
+
+	return result, err
+}
+
+// autocomplete performs search of the candidates where the query string is a prefix of each candidate
+func (s *Service) autocomplete(dictName string, query string, limit int) ([]ResultItem, error) {
 	s.RLock()
 	index, okIndex := s.indexes[dictName]
 	dict, okDict := s.dictionaries[dictName]
+	policy := s.policies[dictName]
+	expansions := s.expansions[dictName]
 	s.RUnlock()
 
 	if !okDict || !okIndex {
-		return nil, fmt.Errorf("given dictionary %s is not exists", dictName)
+		return nil, ErrDictionaryNotFound
+	}
+
+	if limit <= 0 {
+		limit = policy.TopK
+	}
+
+	if err := policy.check(query, limit); err != nil {
+		return nil, err
 	}
 
 	candidates, err := index.Autocomplete(query, NewFirstKCollectorManager(limit))
@@ -150,6 +397,26 @@ func (s *Service) Autocomplete(dictName string, query string, limit int) ([]Resu
 		return nil, err
 	}
 
+	if len(expansions) == 0 {
+		candidates = scoreCandidates(candidates, 0)
+	} else {
+		lists := [][]Candidate{scoreCandidates(candidates, 1)}
+
+		for _, expansion := range expansions {
+			for _, variant := range expansion.expander.Expand(query) {
+				variantCandidates, err := index.Autocomplete(variant, NewFirstKCollectorManager(limit))
+
+				if err != nil {
+					return nil, err
+				}
+
+				lists = append(lists, scoreCandidates(variantCandidates, expansion.weight))
+			}
+		}
+
+		candidates = mergeCandidates(limit, lists)
+	}
+
 	result := make([]ResultItem, 0, len(candidates))
 
 	for _, candidate := range candidates {
@@ -158,8 +425,71 @@ func (s *Service) Autocomplete(dictName string, query string, limit int) ([]Resu
 			return nil, err
 		}
 
-		result = append(result, ResultItem{0, value})
+		result = append(result, ResultItem{candidate.Score, value})
 	}
 
 	return result, nil
 }
+
+// queryExpansion is an expander of the queries with the weight of the candidates found by its variants
+type queryExpansion struct {
+	expander analysis.QueryExpander
+	weight   float64
+}
+
+// weightCandidates multiplies the scores of the candidates by the weight
+func weightCandidates(candidates []Candidate, weight float64) []Candidate {
+	if weight == 1 {
+		return candidates
+	}
+
+	for i := range candidates {
+		candidates[i].Score *= weight
+	}
+
+	return candidates
+}
+
+// mergeCandidates returns the topK candidates of the given lists. A candidate found by several
+// variants of a query takes its best score
+func mergeCandidates(topK int, lists [][]Candidate) []Candidate {
+	scores := map[index.Position]float64{}
+
+	for _, list := range lists {
+		for _, candidate := range list {
+			if score, ok := scores[candidate.Key]; !ok || candidate.Score > score {
+				scores[candidate.Key] = candidate.Score
+			}
+		}
+	}
+
+	queue := NewTopKQueue(topK)
+
+	for key, score := range scores {
+		queue.Add(key, score)
+	}
+
+	return queue.GetCandidates()
+}
+
+// scoreCandidates sets the given score to the candidates. The collected scores of the completions only keep
+// their order, so the completions of a query are unscored and, with the expansions, the completions of the query
+// take the full score and the ones of its variants take the weight
+func scoreCandidates(candidates []Candidate, score float64) []Candidate {
+	for i := range candidates {
+		candidates[i].Score = score
+	}
+
+	return candidates
+}
+
+// observe notifies the observer, if it is set, about the handled request
+func (s *Service) observe(method, dict string, start time.Time, candidates int, err error) {
+	s.RLock()
+	observer := s.observer
+	s.RUnlock()
+
+	if observer != nil {
+		observer(method, dict, time.Since(start), candidates, err)
+	}
+}
@@ -1,10 +1,13 @@
 package suggest
 
 import (
+	"encoding/json"
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/suggest-go/suggest/pkg/analysis"
 	"github.com/suggest-go/suggest/pkg/metric"
 )
 
@@ -86,3 +89,314 @@ func testConcurrency(t *testing.T, driver Driver) {
 
 	wg.Wait()
 }
+
+func TestRequestObserver(t *testing.T) {
+	service := buildService(t, nil)
+
+	type observation struct {
+		method     string
+		dict       string
+		candidates int
+		err        error
+	}
+
+	observations := []observation{}
+	service.SetRequestObserver(func(method, dict string, elapsed time.Duration, candidates int, err error) {
+		observations = append(observations, observation{method, dict, candidates, err})
+	})
+
+	searchConf, err := NewSearchConfig("Nissan March", 5, metric.CosineMetric(), 0.7)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Suggest("cars", searchConf); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Autocomplete("cars", "nissan", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Suggest("unknown", searchConf); err != ErrDictionaryNotFound {
+		t.Errorf("Test fail, expected %v, got %v", ErrDictionaryNotFound, err)
+	}
+
+	expected := []observation{
+		{SuggestMethod, "cars", 1, nil},
+		{AutocompleteMethod, "cars", 3, nil},
+		{SuggestMethod, "unknown", 0, ErrDictionaryNotFound},
+	}
+
+	if !reflect.DeepEqual(observations, expected) {
+		t.Errorf("Test fail, expected %v, got %v", expected, observations)
+	}
+}
+
+func TestGetDictionarySizes(t *testing.T) {
+	service := buildService(t, nil)
+	sizes := service.GetDictionarySizes()
+
+	if len(sizes) != 1 || sizes["cars"] == 0 {
+		t.Errorf("Test fail, expected the size of cars, got %v", sizes)
+	}
+}
+
+func TestGetDictionaryInfos(t *testing.T) {
+	service := buildService(t, nil)
+	descriptions, err := ReadConfigs("testdata/config.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	description := descriptions[0]
+	description.Driver = RAMDriver
+
+	if err := service.AddRunTimeIndex(description); err != nil {
+		t.Fatal(err)
+	}
+
+	infos := service.GetDictionaryInfos()
+
+	if len(infos) != 1 {
+		t.Fatalf("Test fail, expected 1 dictionary, got %v", infos)
+	}
+
+	if infos[0].Name != "cars" || infos[0].Generation != 2 || infos[0].Size == 0 || infos[0].LoadedAt.IsZero() {
+		t.Errorf("Test fail, unexpected info %v", infos[0])
+	}
+}
+
+func TestReplace(t *testing.T) {
+	service := buildService(t, nil)
+	descriptions, err := ReadConfigs("testdata/config.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := NewService()
+
+	for _, description := range descriptions[:2] {
+		description.Driver = RAMDriver
+
+		if err := next.AddRunTimeIndex(description); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service.Replace(next)
+	infos := service.GetDictionaryInfos()
+
+	if len(infos) != 2 || infos[0].Name != "cars" || infos[0].Generation != 2 || infos[1].Generation != 1 {
+		t.Errorf("Test fail, unexpected infos %v", infos)
+	}
+
+	service.Replace(NewService())
+
+	if dictionaries := service.GetDictionaries(); len(dictionaries) != 0 {
+		t.Errorf("Test fail, expected the dropped dictionaries, got %v", dictionaries)
+	}
+
+	if _, err := service.Autocomplete("cars", "nissan", 1); err != ErrDictionaryNotFound {
+		t.Errorf("Test fail, expected %v, got %v", ErrDictionaryNotFound, err)
+	}
+}
+
+func TestQueryAnalysis(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analyzer := &analysis.AnalyzerConfig{
+		Steps: []analysis.AnalyzerStep{
+			{Type: "word"},
+			{Type: "lowercase"},
+			{Type: "wrap", Params: json.RawMessage(`{"start": "$", "end": "$"}`)},
+			{Type: "ngram", Params: json.RawMessage(`{"size": 3}`)},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		configure   func(description *IndexDescription)
+		invalid     bool
+		queries     []string
+		similarity  float64
+		expected    string
+		maxScore    float64
+		prefix      string
+		completions []ResultItem
+	}{
+		{
+			name: "normalization",
+			configure: func(description *IndexDescription) {
+				description.Normalization = &analysis.NormalizationConfig{
+					Steps: []analysis.NormalizationStep{analysis.NFKC, analysis.StripAccents},
+				}
+			},
+			queries:     []string{"nissan juke", "ＮＩＳＳＡＮ ＪＵＫＥ", "nïssan jüke"},
+			similarity:  0.9,
+			expected:    "NISSAN JUKE",
+			maxScore:    1,
+			prefix:      "ｎｉｓｓａｎ ｊｕ",
+			completions: []ResultItem{{0, "NISSAN JUKE"}},
+		},
+		{
+			name: "invalid normalization",
+			configure: func(description *IndexDescription) {
+				description.Normalization = &analysis.NormalizationConfig{Steps: []analysis.NormalizationStep{"unknown"}}
+			},
+			invalid: true,
+		},
+		{
+			name: "keyboard layout",
+			configure: func(description *IndexDescription) {
+				description.QueryExpansion = []string{analysis.KeyboardLayoutExpansion}
+			},
+			queries:    []string{"тшыыфт оглу"},
+			similarity: 0.9,
+			expected:   "NISSAN JUKE",
+			maxScore:   1,
+			prefix:     "тшыы",
+			completions: []ResultItem{
+				{1, "NISSAN 350Z"},
+				{1, "NISSAN ALMERA"},
+				{1, "NISSAN ALTIMA"},
+			},
+		},
+		{
+			name: "unknown query expansion",
+			configure: func(description *IndexDescription) {
+				description.QueryExpansion = []string{"unknown"}
+			},
+			invalid: true,
+		},
+		{
+			name: "analyzer",
+			configure: func(description *IndexDescription) {
+				description.Analyzer = analyzer
+			},
+			queries:     []string{"juke nissan"},
+			similarity:  0.9,
+			expected:    "NISSAN JUKE",
+			maxScore:    1,
+			prefix:      "juke",
+			completions: []ResultItem{{0, "NISSAN JUKE"}},
+		},
+		{
+			name: "invalid analyzer",
+			configure: func(description *IndexDescription) {
+				description.Analyzer = &analysis.AnalyzerConfig{Steps: []analysis.AnalyzerStep{{Type: "unknown"}}}
+			},
+			invalid: true,
+		},
+		{
+			name: "synonyms",
+			configure: func(description *IndexDescription) {
+				description.Synonyms = &SynonymsConfig{File: "synonyms.txt", Penalty: 0.1}
+			},
+			queries:    []string{"chevy aveo"},
+			similarity: 0.5,
+			expected:   "CHEVROLET AVEO",
+			maxScore:   0.9,
+			prefix:     "chevy av",
+			completions: []ResultItem{
+				{0.9, "CHEVROLET AVALANCHE 1500"},
+				{0.9, "CHEVROLET AVALANCHE 2500"},
+				{0.9, "CHEVROLET AVEO"},
+			},
+		},
+		{
+			name: "invalid synonyms penalty",
+			configure: func(description *IndexDescription) {
+				description.Synonyms = &SynonymsConfig{File: "synonyms.txt", Penalty: 1}
+			},
+			invalid: true,
+		},
+		{
+			name: "missing synonyms file",
+			configure: func(description *IndexDescription) {
+				description.Synonyms = &SynonymsConfig{File: "unknown.txt"}
+			},
+			invalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		description := descriptions[0]
+		description.Driver = RAMDriver
+		c.configure(&description)
+
+		service := NewService()
+		err := service.AddRunTimeIndex(description)
+
+		if c.invalid {
+			if err == nil {
+				t.Errorf("Test fail, expected error of %s", c.name)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error %v of %s", err, c.name)
+		}
+
+		for _, query := range c.queries {
+			searchConf, err := NewSearchConfig(query, 1, metric.CosineMetric(), c.similarity)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := service.Suggest("cars", searchConf)
+
+			if err != nil {
+				t.Errorf("Unexpected error %v of %s", err, c.name)
+			}
+
+			if len(result) != 1 || result[0].Value != c.expected || result[0].Score > c.maxScore {
+				t.Errorf("Test fail of %s, expected %v with score at most %v, got %v", c.name, c.expected, c.maxScore, result)
+			}
+		}
+
+		completions, err := service.Autocomplete("cars", c.prefix, 3)
+
+		if err != nil {
+			t.Errorf("Unexpected error %v of %s", err, c.name)
+		}
+
+		if !reflect.DeepEqual(c.completions, completions) {
+			t.Errorf("Test fail of %s, expected %v, got %v", c.name, c.completions, completions)
+		}
+	}
+}
+
+// buildService builds a service with the RAM index of the first test dictionary altered by configure
+func buildService(t *testing.T, configure func(description *IndexDescription)) *Service {
+	descriptions, err := ReadConfigs("testdata/config.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	description := descriptions[0]
+	description.Driver = RAMDriver
+
+	if configure != nil {
+		configure(&description)
+	}
+
+	service := NewService()
+
+	if err := service.AddRunTimeIndex(description); err != nil {
+		t.Fatal(err)
+	}
+
+	return service
+}
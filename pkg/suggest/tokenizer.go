@@ -5,30 +5,52 @@ import (
 	"github.com/suggest-go/suggest/pkg/analysis"
 )
 
-// NewSuggestTokenizer creates a tokenizer for suggester service
-func NewSuggestTokenizer(d IndexDescription) analysis.Tokenizer {
-	filter := analysis.NewNormalizerFilter(alphabet.CreateAlphabet(d.Alphabet), d.Pad)
+// NewSuggestTokenizer creates a tokenizer for suggester service, the declared analyzer replaces the n-gram pipeline
+func NewSuggestTokenizer(d IndexDescription) (analysis.Tokenizer, error) {
+	analyzer, err := d.GetAnalyzer()
 
-	return analysis.NewWrapTokenizer(
-		analysis.NewFilterTokenizer(
-			analysis.NewNGramTokenizer(d.NGramSize),
-			filter,
-		),
-		d.Wrap[0],
-		d.Wrap[1],
-	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTokenizer(d, analyzer, d.Wrap[1])
+}
+
+// NewAutocompleteTokenizer creates a tokenizer for autocomplete service, the declared analyzer replaces the n-gram pipeline
+func NewAutocompleteTokenizer(d IndexDescription) (analysis.Tokenizer, error) {
+	analyzer, err := d.GetAutocompleteAnalyzer()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// do not add a wrap symbol to the tail of query
+	return newTokenizer(d, analyzer, "")
 }
 
-// NewAutocompleteTokenizer creates a tokenizer for autocomplete service
-func NewAutocompleteTokenizer(d IndexDescription) analysis.Tokenizer {
+// newTokenizer wraps the analyzer, or the n-gram pipeline if there is no analyzer, with the normalizer of the description
+func newTokenizer(d IndexDescription, analyzer analysis.Tokenizer, tailWrap string) (analysis.Tokenizer, error) {
+	normalizer, err := d.GetNormalizer()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if analyzer != nil {
+		return analysis.NewNormalizeTokenizer(analyzer, normalizer), nil
+	}
+
 	filter := analysis.NewNormalizerFilter(alphabet.CreateAlphabet(d.Alphabet), d.Pad)
 
-	return analysis.NewWrapTokenizer(
-		analysis.NewFilterTokenizer(
-			analysis.NewNGramTokenizer(d.NGramSize),
-			filter,
+	return analysis.NewNormalizeTokenizer(
+		analysis.NewWrapTokenizer(
+			analysis.NewFilterTokenizer(
+				analysis.NewNGramTokenizer(d.NGramSize),
+				filter,
+			),
+			d.Wrap[0],
+			tailWrap,
 		),
-		d.Wrap[0],
-		"", // do not add a wrap symbol to the tail of query
-	)
+		normalizer,
+	), nil
 }
@@ -0,0 +1,46 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/suggest-go/suggest/pkg/analysis"
+)
+
+func TestNewTokenizerErrors(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalidAnalyzer := descriptions[0]
+	invalidAnalyzer.Analyzer = &analysis.AnalyzerConfig{Steps: []analysis.AnalyzerStep{{Type: "unknown"}}}
+
+	invalidAutocompleteAnalyzer := descriptions[0]
+	invalidAutocompleteAnalyzer.AutocompleteAnalyzer = &analysis.AnalyzerConfig{}
+
+	invalidNormalization := descriptions[0]
+	invalidNormalization.Normalization = &analysis.NormalizationConfig{Steps: []analysis.NormalizationStep{"unknown"}}
+
+	cases := []struct {
+		name            string
+		description     IndexDescription
+		suggestErr      bool
+		autocompleteErr bool
+	}{
+		{"valid", descriptions[0], false, false},
+		{"analyzer", invalidAnalyzer, true, true},
+		{"autocompleteAnalyzer", invalidAutocompleteAnalyzer, false, true},
+		{"normalization", invalidNormalization, true, true},
+	}
+
+	for _, c := range cases {
+		if _, err := NewSuggestTokenizer(c.description); (err != nil) != c.suggestErr {
+			t.Errorf("Test fail, expected suggest error %v of %s, got %v", c.suggestErr, c.name, err)
+		}
+
+		if _, err := NewAutocompleteTokenizer(c.description); (err != nil) != c.autocompleteErr {
+			t.Errorf("Test fail, expected autocomplete error %v of %s, got %v", c.autocompleteErr, c.name, err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"runtime"
+	"sync/atomic"
 
 	"github.com/edsrzf/mmap-go"
 )
@@ -19,6 +20,14 @@ var (
 	ErrMMapInvalidOffset = errors.New("out of range")
 )
 
+// mappedBytes is the total size of the currently mapped regions
+var mappedBytes int64
+
+// MappedBytes returns the total size of the regions currently mapped by MMapReader
+func MappedBytes() int64 {
+	return atomic.LoadInt64(&mappedBytes)
+}
+
 // NewMMapReader returns new instance of MMapReader
 func NewMMapReader(filename string) (*MMapReader, error) {
 	file, err := os.Open(filename)
@@ -39,6 +48,8 @@ func NewMMapReader(filename string) (*MMapReader, error) {
 		data: data,
 	}
 
+	atomic.AddInt64(&mappedBytes, int64(len(data)))
+
 	runtime.SetFinalizer(r, (*MMapReader).Close)
 
 	return r, nil
@@ -59,6 +70,7 @@ func (r *MMapReader) Close() error {
 	r.data = nil
 
 	runtime.SetFinalizer(r, nil)
+	atomic.AddInt64(&mappedBytes, -int64(len(data)))
 
 	return data.Unmap()
 }